@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command gs-metadata writes the configuration metadata of the beans
+// registered by the given packages, as configuration-metadata.json and
+// configuration-schema.json, see gs.WriteConfigMetadata.
+//
+// Usage:
+//
+//	gs-metadata [-o dir] packages...
+//
+// The packages are imported for their bean registrations, so they can't
+// be main packages. The command is meant to be run by go generate, e.g.
+// with the directive in main.go:
+//
+//	//go:generate go run github.com/go-spring/spring-core/cmd/gs-metadata ./service ./dao
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/go-spring/stdlib/errutil"
+)
+
+// generator is the program that imports the packages and writes the
+// metadata. It is run in a temporary directory of the current module,
+// so that the packages resolve against the module's dependencies.
+var generator = template.Must(template.New("generator").Parse(`// Code generated by gs-metadata. DO NOT EDIT.
+
+package main
+
+import (
+{{- range .Packages}}
+	_ {{printf "%q" .}}
+{{- end}}
+
+	"github.com/go-spring/spring-core/gs"
+)
+
+func main() {
+	if err := gs.WriteConfigMetadata({{printf "%q" .Dir}}); err != nil {
+		panic(err)
+	}
+}
+`))
+
+func main() {
+	dir := flag.String("o", ".", "directory to write the metadata files into")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintln(flag.CommandLine.Output(), "usage: gs-metadata [-o dir] packages...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*dir, flag.Args()); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "gs-metadata:", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the metadata of the packages into the directory.
+func run(dir string, patterns []string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	pkgs, err := importPaths(patterns)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.MkdirTemp(".", "gs-metadata-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	f, err := os.Create(filepath.Join(tmp, "main.go"))
+	if err != nil {
+		return err
+	}
+	err = generator.Execute(f, map[string]any{
+		"Packages": pkgs,
+		"Dir":      dir,
+	})
+	if err = errors.Join(err, f.Close()); err != nil {
+		return errutil.Explain(err, "write generator error")
+	}
+
+	cmd := exec.Command("go", "run", "./"+filepath.Base(tmp))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err = cmd.Run(); err != nil {
+		return errutil.Explain(err, "run generator error")
+	}
+	return nil
+}
+
+// importPaths returns the import paths of the packages matched by the
+// patterns, rejecting main packages that can't be imported.
+func importPaths(patterns []string) ([]string, error) {
+	args := append([]string{"list", "-f", "{{.ImportPath}} {{.Name}}"}, patterns...)
+	cmd := exec.Command("go", args...)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errutil.Explain(err, "list packages %v error", patterns)
+	}
+	var ret []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		path, name, _ := strings.Cut(line, " ")
+		if name == "main" {
+			return nil, errutil.Explain(nil, "can't import main package %s", path)
+		}
+		ret = append(ret, path)
+	}
+	return ret, nil
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"testing"
+
+	"github.com/go-spring/stdlib/testing/assert"
+)
+
+func TestImportPaths(t *testing.T) {
+
+	t.Run("packages", func(t *testing.T) {
+		pkgs, err := importPaths([]string{"../../conf", "../../conf/provider"})
+		assert.That(t, err).Nil()
+		assert.That(t, pkgs).Equal([]string{
+			"github.com/go-spring/spring-core/conf",
+			"github.com/go-spring/spring-core/conf/provider",
+		})
+	})
+
+	t.Run("main package", func(t *testing.T) {
+		_, err := importPaths([]string{"."})
+		assert.Error(t, err).Matches("can't import main package github.com/go-spring/spring-core/cmd/gs-metadata")
+	})
+
+	t.Run("unknown package", func(t *testing.T) {
+		_, err := importPaths([]string{"./unknown"})
+		assert.Error(t, err).Matches(`list packages \[./unknown\] error`)
+	})
+}
@@ -19,6 +19,7 @@ package conf
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -88,15 +89,36 @@ func ParseTag(tag string) (ret ParsedTag, err error) {
 		err = errutil.Explain(nil, "invalid syntax tag '%s'", tag)
 		return
 	}
-	ss := strings.SplitN(tag[k+2:j], ":=", 2)
-	ret.Key = strings.TrimSpace(ss[0])
-	if len(ss) > 1 {
+	s := tag[k+2 : j]
+	ret.Key = strings.TrimSpace(s)
+	if i := indexDefault(s); i >= 0 {
+		ret.Key = strings.TrimSpace(s[:i])
 		ret.HasDef = true
-		ret.Def = strings.TrimSpace(ss[1])
+		ret.Def = strings.TrimSpace(s[i+2:])
 	}
 	return
 }
 
+// indexDefault returns the index of the ":=" that starts the default
+// value of a placeholder, skipping those inside nested placeholders
+// and function arguments, or -1 if there is none.
+func indexDefault(s string) int {
+	level := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '{':
+			level++
+		case ')', '}':
+			level--
+		case ':':
+			if level == 0 && i+1 < len(s) && s[i+1] == '=' {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
 // BindParam holds metadata needed to bind a single configuration value
 // to a Go struct field, slice element, or map entry.
 type BindParam struct {
@@ -104,6 +126,7 @@ type BindParam struct {
 	Path     string            // full property path
 	Tag      ParsedTag         // parsed tag
 	Validate reflect.StructTag // original struct field tag for validation
+	Errors   *BindErrors       // if not nil, collects errors instead of failing fast
 }
 
 // BindTag parses the tag string, stores the ParsedTag in BindParam,
@@ -149,14 +172,51 @@ type Filter interface {
 	Do(i any, param BindParam) (bool, error)
 }
 
+// isPropBindingTarget extends typeutil.IsPropBindingTarget to the types,
+// and the collections of types, that have a registered converter or
+// implement an unmarshaler, such as *url.URL or []net.IP, as well as to
+// `any` and to pointers to binding targets.
+func isPropBindingTarget(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		if _, ok := converters[t.Elem()]; ok {
+			return true
+		}
+		if implementsUnmarshaler(t.Elem()) || isAnyType(t.Elem()) {
+			return true
+		}
+		if e := t.Elem(); e.Kind() == reflect.Pointer && isPropBindingTarget(e) {
+			return true
+		}
+	case reflect.Pointer:
+		if e := t.Elem(); e.Kind() != reflect.Pointer && isPropBindingTarget(e) {
+			return true
+		}
+	case reflect.Interface:
+		return isAnyType(t)
+	default: // for linter
+	}
+	return typeutil.IsPropBindingTarget(t) || implementsUnmarshaler(t)
+}
+
+// isAnyType reports whether t is the empty interface.
+func isAnyType(t reflect.Type) bool {
+	return t.Kind() == reflect.Interface && t.NumMethod() == 0
+}
+
 // BindValue attempts to bind a property value from the property source `p`
 // into the given reflect.Value `v`, based on metadata in `param`.
 //
 // Supported binding targets:
+// - Types with a registered converter (checked first).
+// - Types implementing Unmarshaler, TextUnmarshaler or JSON Unmarshaler.
 // - Primitive types (string, int, float, bool, etc.).
 // - Structs (recursively bound field by field).
 // - Maps (bound by iterating subkeys).
 // - Slices (bound by either indexed keys or split strings).
+// - Arrays (like slices, with at most as many elements as the array length).
+// - Pointers (nil if the property is absent, allocated if it is present).
+// - `any` (the raw value, e.g. a string, []any or map[string]any).
 //
 // Errors:
 // - Returns not exist if the property is missing without a default.
@@ -164,35 +224,48 @@ type Filter interface {
 // - Returns wrapped errors with context (path, type).
 func BindValue(p flatten.Storage, v reflect.Value, t reflect.Type, param BindParam, filter Filter) (RetErr error) {
 
-	if !typeutil.IsPropBindingTarget(t) {
+	fn := converters[t]
+	if fn == nil && !isPropBindingTarget(t) {
 		err := errutil.Explain(nil, "target should be value type")
-		return errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		return collectError(param, t, "", errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String()))
 	}
 
-	// run validation if "expr" tag is defined and no prior error
+	// the pointed value is validated instead of the pointer.
+	if fn == nil && t.Kind() == reflect.Pointer && !usesUnmarshaler(p, t, param) {
+		return bindPointer(p, v, t, param, filter)
+	}
+
+	// run "validate" and "expr" validation if no prior error
+	numErrors := param.Errors.Len()
 	defer func() {
-		if RetErr == nil {
-			tag, ok := param.Validate.Lookup("expr")
-			if ok && len(tag) > 0 {
-				if RetErr = validateField(tag, v.Interface()); RetErr != nil {
-					RetErr = errutil.Explain(RetErr, "validate path=%s type=%s error", param.Path, v.Type().String())
-				}
+		if RetErr == nil && param.Errors.Len() == numErrors && param.Validate != "" {
+			if err := validateValue(v, param.Validate); err != nil {
+				err = errutil.Explain(err, "validate path=%s type=%s error", param.Path, v.Type().String())
+				RetErr = collectError(param, t, fmt.Sprint(v.Interface()), err)
 			}
 		}
 	}()
 
-	switch v.Kind() {
-	case reflect.Map:
-		return bindMap(p, v, t, param, filter)
-	case reflect.Slice:
-		return bindSlice(p, v, t, param, filter)
-	case reflect.Array:
-		err := errutil.Explain(nil, "use slice instead of array")
-		return errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
-	default: // for linter
+	if fn == nil {
+		if ok, err := bindUnmarshaler(p, v, t, param); ok {
+			return err // no wrap
+		}
+	}
+
+	if fn == nil {
+		switch v.Kind() {
+		case reflect.Map:
+			return bindMap(p, v, t, param, filter)
+		case reflect.Slice:
+			return bindSlice(p, v, t, param, filter)
+		case reflect.Array:
+			return bindArray(p, v, t, param, filter)
+		case reflect.Interface:
+			return bindAny(p, v, param)
+		default: // for linter
+		}
 	}
 
-	fn := converters[t]
 	if fn == nil && v.Kind() == reflect.Struct {
 		if err := bindStruct(p, v, t, param, filter); err != nil {
 			return err // no wrap
@@ -201,9 +274,9 @@ func BindValue(p flatten.Storage, v reflect.Value, t reflect.Type, param BindPar
 	}
 
 	// resolve property value (with default and references)
-	val, err := resolve(p, param)
+	val, err := resolve(p, param, nil)
 	if err != nil {
-		return errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		return collectError(param, t, "", errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String()))
 	}
 	if val = strings.TrimSpace(val); val == "" {
 		return nil
@@ -215,7 +288,7 @@ func BindValue(p flatten.Storage, v reflect.Value, t reflect.Type, param BindPar
 		out := fnValue.Call([]reflect.Value{reflect.ValueOf(val)})
 		if !out[1].IsNil() {
 			err = out[1].Interface().(error)
-			return errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+			return collectError(param, t, val, errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String()))
 		}
 		v.Set(out[0])
 		return nil
@@ -229,28 +302,28 @@ func BindValue(p flatten.Storage, v reflect.Value, t reflect.Type, param BindPar
 			v.SetUint(u)
 			return nil
 		}
-		return errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		return collectError(param, t, val, errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String()))
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		var i int64
 		if i, err = strconv.ParseInt(val, 0, 0); err == nil {
 			v.SetInt(i)
 			return nil
 		}
-		return errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		return collectError(param, t, val, errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String()))
 	case reflect.Float32, reflect.Float64:
 		var f float64
 		if f, err = strconv.ParseFloat(val, 64); err == nil {
 			v.SetFloat(f)
 			return nil
 		}
-		return errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		return collectError(param, t, val, errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String()))
 	case reflect.Bool:
 		var b bool
 		if b, err = strconv.ParseBool(val); err == nil {
 			v.SetBool(b)
 			return nil
 		}
-		return errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		return collectError(param, t, val, errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String()))
 	default:
 		// treat everything else as string
 		v.SetString(val)
@@ -265,42 +338,181 @@ func BindValue(p flatten.Storage, v reflect.Value, t reflect.Type, param BindPar
 //     e.g. "list[0]=a", "list[1]=b"
 //  2. A single delimited string:
 //     e.g. "list=a,b,c"  (split by ",")
-//
-// The slice is always reset (v.Set(slice)) before return,
-// even if binding fails midway.
 func bindSlice(p flatten.Storage, v reflect.Value, t reflect.Type, param BindParam, filter Filter) error {
+	slice, err := bindElements(p, v, t, param, filter)
+	if err != nil {
+		return err // no wrap
+	}
+	v.Set(slice)
+	return nil
+}
 
-	elemType := t.Elem()
-	p, err := getSlice(p, param)
+// bindArray binds configuration values into an array of type [N]T, in the
+// same formats as bindSlice. It fails if there are more than N elements;
+// the elements after the bound ones are left zero.
+func bindArray(p flatten.Storage, v reflect.Value, t reflect.Type, param BindParam, filter Filter) error {
+	slice, err := bindElements(p, v, reflect.SliceOf(t.Elem()), param, filter)
 	if err != nil {
-		return errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		return err // no wrap
+	}
+	if slice.Len() > t.Len() {
+		err = errutil.Explain(nil, "array length is %d but got %d elements", t.Len(), slice.Len())
+		return collectError(param, t, "", errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String()))
 	}
+	arr := reflect.New(t).Elem()
+	reflect.Copy(arr, slice)
+	v.Set(arr)
+	return nil
+}
 
+// bindElements binds configuration values into a new slice of type t,
+// for the slice or array target v.
+func bindElements(p flatten.Storage, v reflect.Value, t reflect.Type, param BindParam, filter Filter) (reflect.Value, error) {
+
+	elemType := t.Elem()
 	slice := reflect.MakeSlice(t, 0, 0)
+	p, err := getSlice(p, param)
+	if err != nil {
+		err = errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		return slice, collectError(param, v.Type(), "", err)
+	}
 	if p == nil {
-		v.Set(slice)
-		return nil
+		return slice, nil
 	}
 
 	for i := 0; ; i++ {
 		subValue := reflect.New(elemType).Elem()
 		subParam := BindParam{
-			Key:  fmt.Sprintf("%s[%d]", param.Key, i),
-			Path: fmt.Sprintf("%s[%d]", param.Path, i),
+			Key:    fmt.Sprintf("%s[%d]", param.Key, i),
+			Path:   fmt.Sprintf("%s[%d]", param.Path, i),
+			Errors: param.Errors,
 		}
 		if !p.Exists(subParam.Key) {
 			break // stop when no more indexed elements
 		}
 		err = BindValue(p, subValue, elemType, subParam, filter)
 		if err != nil {
-			return errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+			err = errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+			return reflect.Value{}, err
 		}
 		slice = reflect.Append(slice, subValue)
 	}
-	v.Set(slice)
+	return slice, nil
+}
+
+// bindPointer binds a pointer to a struct or to a scalar value. The pointer
+// is set to nil if the property is absent and has no non-empty default,
+// so optional configuration blocks can be modelled as pointers, e.g.:
+//
+//	type ServerConfig struct {
+//	    TLS *TLSConfig `value:"${tls}"` // nil unless tls.* keys exist
+//	}
+func bindPointer(p flatten.Storage, v reflect.Value, t reflect.Type, param BindParam, filter Filter) error {
+	if param.Key != "" && !lookupExists(p, param.Key) {
+		if !param.Tag.HasDef || param.Tag.Def == "" {
+			v.SetZero()
+			if hasRule(param.Validate, "required") {
+				err := errutil.Explain(nil, "validate failed on %q for value <nil>", "required")
+				err = errutil.Explain(err, "validate path=%s type=%s error", param.Path, v.Type().String())
+				return collectError(param, t, "", err)
+			}
+			return nil
+		}
+	}
+	ptr := reflect.New(t.Elem())
+	if err := BindValue(p, ptr.Elem(), t.Elem(), param, filter); err != nil {
+		return err // no wrap
+	}
+	v.Set(ptr)
+	return nil
+}
+
+// bindAny binds the raw value of a property into an `any` target: a string
+// for a simple value, []any for a slice and map[string]any for a map.
+func bindAny(p flatten.Storage, v reflect.Value, param BindParam) error {
+	val, ok, err := rawValue(p, param.Key)
+	if err != nil {
+		return collectError(param, v.Type(), "", errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String()))
+	}
+	if !ok {
+		if !param.Tag.HasDef {
+			err = errutil.Explain(nil, "property %q not exist", param.Key)
+			return collectError(param, v.Type(), "", errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String()))
+		}
+		if param.Tag.Def == "" {
+			v.SetZero()
+			return nil
+		}
+		if val, err = resolveValue(p, param.Key, param.Tag.Def, nil); err != nil {
+			return collectError(param, v.Type(), param.Tag.Def, errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String()))
+		}
+	}
+	v.Set(reflect.ValueOf(&val).Elem())
 	return nil
 }
 
+// rawValue returns the raw value of a property, resolving references
+// in simple values. It returns false if the property is absent.
+func rawValue(p flatten.Storage, key string) (any, bool, error) {
+	if key != "" {
+		k, val, ok, err := lookupValue(p, key)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			s, err := resolveValue(p, k, val, []string{k})
+			if err != nil {
+				return nil, false, err
+			}
+			return s, true, nil
+		}
+	}
+
+	entries := make(map[string]string)
+	if key != "" && lookupSliceEntries(p, key, entries) {
+		n := 0
+		for k := range entries {
+			str := strings.TrimPrefix(k, key+"[")
+			if i := strings.IndexByte(str, ']'); i > 0 {
+				if idx, err := strconv.Atoi(str[:i]); err == nil && idx >= n {
+					n = idx + 1
+				}
+			}
+		}
+		s := flatten.NewPropertiesStorage(flatten.NewProperties(entries))
+		arr := make([]any, n)
+		for i := range arr {
+			var err error
+			if arr[i], _, err = rawValue(s, fmt.Sprintf("%s[%d]", key, i)); err != nil {
+				return nil, false, err
+			}
+		}
+		return arr, true, nil
+	}
+
+	keySet := make(map[string]struct{})
+	prefix := lookupMapKeys(p, key, keySet)
+	if len(keySet) == 0 {
+		return nil, false, nil
+	}
+	m := make(map[string]any)
+	for k := range keySet {
+		if i := strings.IndexByte(k, '['); i >= 0 {
+			k = k[:i]
+		}
+		subKey := k
+		if prefix != "" {
+			subKey = prefix + "." + k
+		}
+		val, _, err := rawValue(p, subKey)
+		if err != nil {
+			return nil, false, err
+		}
+		m[k] = val
+	}
+	return m, true, nil
+}
+
 // getSlice prepares a Storage object representing slice elements
 // derived from either:
 //
@@ -312,12 +524,15 @@ func bindSlice(p flatten.Storage, v reflect.Value, t reflect.Type, param BindPar
 func getSlice(p flatten.Storage, param BindParam) (flatten.Storage, error) {
 
 	m := make(map[string]string)
-	if p.SliceEntries(param.Key, m) {
+	if lookupSliceEntries(p, param.Key, m) {
 		return flatten.NewPropertiesStorage(flatten.NewProperties(m)), nil
 	}
 
 	// case 2: property is a single string -> split into slice
-	strVal, ok := p.Value(param.Key)
+	_, strVal, ok, err := lookupValue(p, param.Key)
+	if err != nil {
+		return nil, err
+	}
 	if !ok {
 		if !param.Tag.HasDef {
 			return nil, errutil.Explain(nil, "property %q not exist", param.Key)
@@ -359,7 +574,7 @@ func bindMap(p flatten.Storage, v reflect.Value, t reflect.Type, param BindParam
 
 	if param.Tag.HasDef && param.Tag.Def != "" {
 		err := errutil.Explain(nil, "map can't have a non-empty default value")
-		return errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		return collectError(param, t, param.Tag.Def, errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String()))
 	}
 
 	elemType := t.Elem()
@@ -376,24 +591,26 @@ func bindMap(p flatten.Storage, v reflect.Value, t reflect.Type, param BindParam
 	// Allow `param.Key` to be an empty string,
 	// to retrieve all configuration items.
 	keySet := make(map[string]struct{})
-	p.MapKeys(param.Key, keySet)
+	prefix := lookupMapKeys(p, param.Key, keySet)
 	if len(keySet) == 0 {
 		if param.Tag.HasDef {
 			v.Set(ret)
 			return nil
 		}
-		return errutil.Explain(nil, "map property %q not exist", param.Key)
+		err := errutil.Explain(nil, "map property %q not exist", param.Key)
+		return collectError(param, t, "", err)
 	}
 
 	for key := range keySet {
 		subValue := reflect.New(elemType).Elem()
 		subKey := key
 		if param.Key != "" {
-			subKey = param.Key + "." + key
+			subKey = prefix + "." + key
 		}
 		subParam := BindParam{
-			Key:  subKey,
-			Path: param.Path,
+			Key:    subKey,
+			Path:   param.Path,
+			Errors: param.Errors,
 		}
 		if err := BindValue(p, subValue, elemType, subParam, filter); err != nil {
 			return err // no wrap
@@ -426,9 +643,10 @@ func bindStruct(p flatten.Storage, v reflect.Value, t reflect.Type, param BindPa
 
 	if param.Tag.HasDef && param.Tag.Def != "" {
 		err := errutil.Explain(nil, "struct can't have a non-empty default value")
-		return errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		return collectError(param, t, param.Tag.Def, errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String()))
 	}
 
+	numErrors := param.Errors.Len()
 	for i := range t.NumField() {
 		ft := t.Field(i)
 		fv := v.Field(i)
@@ -439,13 +657,18 @@ func bindStruct(p flatten.Storage, v reflect.Value, t reflect.Type, param BindPa
 		}
 
 		subParam := BindParam{
-			Key:  param.Key,
-			Path: param.Path + "." + ft.Name,
+			Key:    param.Key,
+			Path:   param.Path + "." + ft.Name,
+			Errors: param.Errors,
 		}
 
 		if tag, ok := ft.Tag.Lookup("value"); ok {
 			if err := subParam.BindTag(tag, ft.Tag); err != nil {
-				return errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+				err = errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+				if err = collectError(subParam, ft.Type, tag, err); err != nil {
+					return err
+				}
+				continue
 			}
 			if filter != nil {
 				ret, err := filter.Do(fv.Addr().Interface(), subParam)
@@ -463,20 +686,65 @@ func bindStruct(p flatten.Storage, v reflect.Value, t reflect.Type, param BindPa
 		}
 
 		if ft.Anonymous {
-			// embed pointer type may lead to infinite recursion.
-			if ft.Type.Kind() != reflect.Struct {
-				continue
-			}
-			if err := bindStruct(p, fv, ft.Type, subParam, filter); err != nil {
-				return err // no wrap
+			switch {
+			case ft.Type.Kind() == reflect.Struct:
+				if err := bindStruct(p, fv, ft.Type, subParam, filter); err != nil {
+					return err // no wrap
+				}
+			case ft.Type.Kind() == reflect.Pointer && ft.Type.Elem().Kind() == reflect.Struct:
+				// embed pointer type may lead to infinite recursion.
+				if embedsPointerTo(ft.Type.Elem(), t, nil) {
+					continue
+				}
+				ptr := reflect.New(ft.Type.Elem())
+				if err := bindStruct(p, ptr.Elem(), ft.Type.Elem(), subParam, filter); err != nil {
+					return err // no wrap
+				}
+				fv.Set(ptr)
+			default: // for linter
 			}
 		}
 	}
-	return nil
+
+	// cross-field rules need all fields to be bound
+	if param.Errors.Len() > numErrors {
+		return nil
+	}
+	return ValidateCrossFields(v, param)
+}
+
+// embedsPointerTo reports whether struct type t embeds, directly or through
+// other embedded structs, a pointer to the struct type target.
+func embedsPointerTo(t reflect.Type, target reflect.Type, visited map[reflect.Type]bool) bool {
+	if t == target {
+		return true
+	}
+	if visited == nil {
+		visited = make(map[reflect.Type]bool)
+	} else if visited[t] {
+		return false
+	}
+	visited[t] = true
+	for i := range t.NumField() {
+		ft := t.Field(i)
+		if !ft.Anonymous {
+			continue
+		}
+		et := ft.Type
+		if et.Kind() == reflect.Pointer {
+			et = et.Elem()
+		}
+		if et.Kind() == reflect.Struct && embedsPointerTo(et, target, visited) {
+			return true
+		}
+	}
+	return false
 }
 
 // resolve fetches the final string value of a property key,
 // applying default values and resolving references recursively.
+// The chain holds the keys being resolved, to detect circular
+// references like "a=${b}" and "b=${a}".
 //
 // Example:
 //
@@ -485,15 +753,50 @@ func bindStruct(p flatten.Storage, v reflect.Value, t reflect.Type, param BindPa
 //	  "url"  = "http://${host}:8080"
 //
 //	resolve(url) -> "http://localhost:8080"
-func resolve(p flatten.Storage, param BindParam) (string, error) {
-	if val, ok := p.Value(param.Key); ok {
-		return Resolve(p, val)
+func resolve(p flatten.Storage, param BindParam, chain []string) (string, error) {
+	key, val, ok, err := lookupValue(p, param.Key)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		if slices.Contains(chain, key) {
+			return "", errutil.Explain(nil, "circular reference %s -> %s", strings.Join(chain, " -> "), key)
+		}
+		return resolveValue(p, key, val, append(slices.Clip(chain), key))
+	}
+	if fn, args, bare, ok := parseCall(param.Tag.Key); ok {
+		if !bare {
+			s, err := callPlaceholder(p, param.Tag.Key, fn, args, chain)
+			if err != nil && param.Tag.HasDef {
+				return resolveValue(p, param.Key, param.Tag.Def, chain)
+			}
+			return s, err
+		}
+		if param.Key == param.Tag.Key && !p.Exists(param.Key) {
+			if s, err := callPlaceholder(p, param.Tag.Key, fn, args, chain); err == nil {
+				return s, nil
+			}
+		}
 	}
 	if p.Exists(param.Key) {
 		return "", errutil.Explain(nil, "property %q isn't simple value", param.Key)
 	}
 	if param.Tag.HasDef {
-		return Resolve(p, param.Tag.Def)
+		return resolveValue(p, param.Key, param.Tag.Def, chain)
 	}
 	return "", errutil.Explain(nil, "property %q not exist", param.Key)
 }
+
+// resolveValue decrypts an encrypted value, e.g. "{cipher}BASE64", or
+// otherwise resolves the references in it. Decrypted values are taken
+// literally and not resolved again.
+func resolveValue(p flatten.Storage, key string, val string, chain []string) (string, error) {
+	if _, _, ok := parseEncrypted(val); ok {
+		s, err := decrypt(val)
+		if err != nil {
+			return "", errutil.Explain(err, "property %q", key)
+		}
+		return s, nil
+	}
+	return resolveString(p, val, chain)
+}
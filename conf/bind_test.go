@@ -17,9 +17,16 @@
 package conf_test
 
 import (
+	"encoding/json"
+	"errors"
 	"image"
 	"io"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -69,6 +76,123 @@ func TestConverter(t *testing.T) {
 		err := conf.Bind(p, &s)
 		assert.Error(t, err).Matches("unable to parse date: 2025-02-01M00:00:00")
 	})
+
+	t.Run("typed types", func(t *testing.T) {
+		var v struct {
+			URL      *url.URL                 `value:"${url}"`
+			IP       net.IP                   `value:"${ip}"`
+			IPs      []net.IP                 `value:"${ips}"`
+			IPNet    *net.IPNet               `value:"${ip-net}"`
+			Addr     netip.Addr               `value:"${addr}"`
+			Prefix   netip.Prefix             `value:"${prefix}"`
+			Regexp   *regexp.Regexp           `value:"${regexp}"`
+			Mode     os.FileMode              `value:"${mode}"`
+			Size     conf.ByteSize            `value:"${size}"`
+			Sizes    map[string]conf.ByteSize `value:"${sizes}"`
+			Location *time.Location           `value:"${location}"`
+			Empty    *url.URL                 `value:"${empty:=}"`
+		}
+		p := flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"url":      "https://example.com:8443/api?x=1",
+			"ip":       "10.0.0.1",
+			"ips":      "10.0.0.1, ::1",
+			"ip-net":   "192.168.0.0/16",
+			"addr":     "fe80::1",
+			"prefix":   "10.0.0.0/8",
+			"regexp":   "^a+$",
+			"mode":     "0644",
+			"size":     "10MB",
+			"sizes":    map[string]any{"read": "1GiB", "write": "512"},
+			"location": "Asia/Shanghai",
+		}))
+		err := conf.Bind(p, &v)
+		assert.That(t, err).Nil()
+		assert.That(t, v.URL.Host).Equal("example.com:8443")
+		assert.That(t, v.IP.String()).Equal("10.0.0.1")
+		assert.That(t, len(v.IPs)).Equal(2)
+		assert.That(t, v.IPs[1].String()).Equal("::1")
+		assert.That(t, v.IPNet.String()).Equal("192.168.0.0/16")
+		assert.That(t, v.Addr).Equal(netip.MustParseAddr("fe80::1"))
+		assert.That(t, v.Prefix).Equal(netip.MustParsePrefix("10.0.0.0/8"))
+		assert.That(t, v.Regexp.MatchString("aaa")).True()
+		assert.That(t, v.Mode).Equal(os.FileMode(0644))
+		assert.That(t, v.Size).Equal(10 * conf.MB)
+		assert.That(t, v.Sizes).Equal(map[string]conf.ByteSize{"read": conf.GB, "write": 512})
+		assert.That(t, v.Location.String()).Equal("Asia/Shanghai")
+		assert.That(t, v.Empty).Nil()
+	})
+
+	t.Run("invalid typed values", func(t *testing.T) {
+		for _, c := range []struct {
+			value any
+			tag   string
+			err   string
+		}{
+			{&struct{ V net.IP }{}, "${:=10.0.0}", `invalid IP address "10.0.0"`},
+			{&struct{ V *net.IPNet }{}, "${:=10.0.0.0}", `invalid CIDR address`},
+			{&struct{ V netip.Addr }{}, "${:=x}", `ParseAddr\("x"\)`},
+			{&struct{ V *regexp.Regexp }{}, "${:=(}", `missing closing \)`},
+			{&struct{ V os.FileMode }{}, "${:=0999}", `invalid file mode "0999"`},
+			{&struct{ V conf.ByteSize }{}, "${:=10XB}", `invalid byte size "10XB"`},
+			{&struct{ V *time.Location }{}, "${:=Mars/Olympus}", `unknown time zone`},
+		} {
+			v := reflect.ValueOf(c.value).Elem().Field(0)
+			err := conf.Bind(flatten.NewPropertiesStorage(flatten.NewProperties(nil)), v, c.tag)
+			assert.Error(t, err).Matches(c.err)
+		}
+	})
+
+	t.Run("override", func(t *testing.T) {
+		type Level int
+		t.Cleanup(conf.SaveConverter[Level]())
+		conf.RegisterConverter(func(s string) (Level, error) { return 1, nil })
+		assert.Panic(t, func() {
+			conf.RegisterConverter(func(s string) (Level, error) { return 2, nil })
+		}, "converter for type conf_test.Level already exists")
+		conf.OverrideConverter(func(s string) (Level, error) { return 3, nil })
+		var l Level
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.NewProperties(nil)), &l, "${:=x}")
+		assert.That(t, err).Nil()
+		assert.That(t, l).Equal(Level(3))
+	})
+
+	t.Run("replace built-in", func(t *testing.T) {
+		t.Cleanup(conf.SaveConverter[os.FileMode]())
+		conf.RegisterConverter(func(s string) (os.FileMode, error) { return 0600, nil })
+		assert.Panic(t, func() {
+			conf.RegisterConverter(func(s string) (os.FileMode, error) { return 0644, nil })
+		}, "converter for type fs.FileMode already exists")
+		var m os.FileMode
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.NewProperties(nil)), &m, "${:=x}")
+		assert.That(t, err).Nil()
+		assert.That(t, m).Equal(os.FileMode(0600))
+	})
+}
+
+func TestParseByteSize(t *testing.T) {
+	for _, c := range []struct {
+		s    string
+		size conf.ByteSize
+		str  string
+	}{
+		{"0", 0, "0B"},
+		{"512", 512, "512B"},
+		{"1536B", 1536, "1536B"},
+		{"10kb", 10 * conf.KB, "10KB"},
+		{"1.5 MB", 1536 * conf.KB, "1536KB"},
+		{"1GiB", conf.GB, "1GB"},
+		{"2T", 2 * conf.TB, "2TB"},
+		{"1PB", conf.PB, "1PB"},
+	} {
+		size, err := conf.ParseByteSize(c.s)
+		assert.That(t, err).Nil()
+		assert.That(t, size).Equal(c.size)
+		assert.That(t, size.String()).Equal(c.str)
+	}
+	for _, s := range []string{"", "MB", "1..5MB", "10XB", "-1MB", "9000PB"} {
+		_, err := conf.ParseByteSize(s)
+		assert.That(t, err).NotNil()
+	}
 }
 
 func TestParseTag(t *testing.T) {
@@ -295,7 +419,7 @@ func TestProperties_Bind(t *testing.T) {
 	})
 
 	t.Run("pointer to pointer target", func(t *testing.T) {
-		err := conf.Bind(flatten.NewPropertiesStorage(flatten.NewProperties(nil)), new(*int))
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.NewProperties(nil)), new(**int))
 		assert.Error(t, err).Matches("target should be value type")
 	})
 
@@ -311,9 +435,9 @@ func TestProperties_Bind(t *testing.T) {
 
 	t.Run("array error", func(t *testing.T) {
 		err := conf.Bind(flatten.NewPropertiesStorage(flatten.NewProperties(nil)), new(struct {
-			Arr [3]string `value:"${arr:=1,2,3}"`
+			Arr [2]string `value:"${arr:=1,2,3}"`
 		}))
-		assert.Error(t, err).Matches("use slice instead of array")
+		assert.Error(t, err).Matches("array length is 2 but got 3 elements")
 	})
 
 	t.Run("string to int error", func(t *testing.T) {
@@ -849,3 +973,534 @@ func TestStructBinding(t *testing.T) {
 		})
 	})
 }
+
+// layeredStorage is a conf.LayeredStorage of fixed layers.
+type layeredStorage []conf.Layer
+
+func (l layeredStorage) Layers() []conf.Layer {
+	return l
+}
+
+func (l layeredStorage) Exists(key string) bool {
+	for _, layer := range l {
+		if layer.Exists(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l layeredStorage) Value(key string) (string, bool) {
+	for _, layer := range l {
+		if v, ok := layer.Value(key); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func (l layeredStorage) MapKeys(key string, result map[string]struct{}) bool {
+	var found bool
+	for _, layer := range l {
+		if layer.MapKeys(key, result) {
+			found = true
+		}
+	}
+	return found
+}
+
+func (l layeredStorage) SliceEntries(key string, result map[string]string) bool {
+	for _, layer := range l {
+		if layer.SliceEntries(key, result) {
+			return true
+		}
+	}
+	return false
+}
+
+func props(m map[string]any) flatten.Storage {
+	return flatten.NewPropertiesStorage(flatten.MapProperties(m))
+}
+
+func TestRelaxedBinding(t *testing.T) {
+
+	type ServerConfig struct {
+		ReadTimeout  time.Duration `value:"${readTimeout}"`
+		WriteTimeout time.Duration `value:"${write-timeout:=1s}"`
+		IdleTimeout  time.Duration `value:"${idle_timeout:=1s}"`
+	}
+
+	t.Run("spellings", func(t *testing.T) {
+		for _, m := range []map[string]any{
+			{"server.read-timeout": "3s"},
+			{"server.readTimeout": "3s"},
+			{"server.read_timeout": "3s"},
+			{"SERVER_READ_TIMEOUT": "3s"},
+			{"server.read.timeout": "3s"},
+		} {
+			var s ServerConfig
+			err := conf.Bind(flatten.NewPropertiesStorage(flatten.MapProperties(m)), &s, "${server}")
+			assert.That(t, err).Nil()
+			assert.That(t, s.ReadTimeout).Equal(3 * time.Second)
+		}
+	})
+
+	t.Run("all fields", func(t *testing.T) {
+		var s ServerConfig
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"server.readTimeout":   "3s",
+			"server.write_timeout": "4s",
+			"server.idle-timeout":  "5s",
+		})), &s, "${server}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal(ServerConfig{
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 4 * time.Second,
+			IdleTimeout:  5 * time.Second,
+		})
+	})
+
+	t.Run("env overrides", func(t *testing.T) {
+		var s ServerConfig
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"server.read-timeout": "3s",
+			"SERVER_READ_TIMEOUT": "6s",
+		})), &s, "${server}")
+		assert.That(t, err).Nil()
+		assert.That(t, s.ReadTimeout).Equal(6 * time.Second)
+	})
+
+	t.Run("same value", func(t *testing.T) {
+		var s ServerConfig
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"server.read-timeout": "3s",
+			"server.readTimeout":  "3s",
+		})), &s, "${server}")
+		assert.That(t, err).Nil()
+		assert.That(t, s.ReadTimeout).Equal(3 * time.Second)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		var s ServerConfig
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"server.read-timeout": "3s",
+			"server.readTimeout":  "4s",
+		})), &s, "${server}")
+		assert.Error(t, err).Matches(`property "server.readTimeout" conflicts with "server.read-timeout"`)
+	})
+
+	t.Run("env conflict", func(t *testing.T) {
+		var s ServerConfig
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"SERVER_READ_TIMEOUT": "3s",
+			"server.read.timeout": "4s",
+		})), &s, "${server}")
+		assert.Error(t, err).Matches(`property "SERVER_READ_TIMEOUT" conflicts with "server.read.timeout"`)
+	})
+
+	t.Run("single segment ignores env", func(t *testing.T) {
+		var s struct {
+			Home string `value:"${home:=none}"`
+		}
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"HOME": "/root",
+		})), &s)
+		assert.That(t, err).Nil()
+		assert.That(t, s.Home).Equal("none")
+	})
+
+	t.Run("layers", func(t *testing.T) {
+		var s ServerConfig
+		err := conf.Bind(layeredStorage{
+			{Storage: props(map[string]any{"server.read-timeout": "1s"})},
+			{Storage: props(map[string]any{"SERVER_READ_TIMEOUT": "2s", "server.write.timeout": "2s"}), Env: true},
+			{Storage: props(map[string]any{"server.idle_timeout": "3s", "SERVER_WRITE_TIMEOUT": "3s"})},
+			{Storage: props(map[string]any{"server.idle-timeout": "4s"})},
+		}, &s, "${server}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal(ServerConfig{
+			ReadTimeout:  time.Second,
+			WriteTimeout: 2 * time.Second,
+			IdleTimeout:  3 * time.Second,
+		})
+	})
+
+	t.Run("env form only in env layers", func(t *testing.T) {
+		var s ServerConfig
+		err := conf.Bind(layeredStorage{
+			{Storage: props(map[string]any{"SERVER_READ_TIMEOUT": "2s"})},
+			{Storage: props(map[string]any{"server.read-timeout": "3s"})},
+		}, &s, "${server}")
+		assert.That(t, err).Nil()
+		assert.That(t, s.ReadTimeout).Equal(3 * time.Second)
+	})
+
+	t.Run("slice and map", func(t *testing.T) {
+		var s struct {
+			AllowedHosts []string       `value:"${app.allowedHosts}"`
+			MaxConns     []int          `value:"${app.maxConns}"`
+			PoolSizes    map[string]int `value:"${app.poolSizes}"`
+			Endpoints    map[string]struct {
+				BaseURL string `value:"${baseUrl}"`
+			} `value:"${app.endpoints}"`
+		}
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"app.allowed-hosts":            []any{"a", "b"},
+			"app.max_conns":                "1,2",
+			"app.pool-sizes.db":            "8",
+			"app.endpoints.user.base-url":  "http://user",
+			"app.endpoints.order.base_url": "http://order",
+		})), &s)
+		assert.That(t, err).Nil()
+		assert.That(t, s.AllowedHosts).Equal([]string{"a", "b"})
+		assert.That(t, s.MaxConns).Equal([]int{1, 2})
+		assert.That(t, s.PoolSizes).Equal(map[string]int{"db": 8})
+		assert.That(t, s.Endpoints["user"].BaseURL).Equal("http://user")
+		assert.That(t, s.Endpoints["order"].BaseURL).Equal("http://order")
+	})
+}
+
+type Color int
+
+func (c *Color) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "red":
+		*c = 1
+	case "green":
+		*c = 2
+	default:
+		return errutil.Explain(nil, "unknown color %q", text)
+	}
+	return nil
+}
+
+type Level struct {
+	Name string
+}
+
+func (l *Level) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &l.Name)
+}
+
+type Endpoint struct {
+	Host  string
+	Port  int
+	Hosts []string
+}
+
+func (e *Endpoint) UnmarshalProperties(p flatten.Storage) error {
+	if s, ok := p.Value(""); ok {
+		host, port, _ := strings.Cut(s, ":")
+		e.Host, e.Port = host, cast.ToInt(port)
+		return nil
+	}
+	if err := conf.Bind(p, &e.Host, "${host}"); err != nil {
+		return err
+	}
+	if err := conf.Bind(p, &e.Port, "${port:=80}"); err != nil {
+		return err
+	}
+	return conf.Bind(p, &e.Hosts, "${hosts:=}")
+}
+
+type JSONDB struct {
+	Host string `value:"${host}" json:"host"`
+	Port int    `value:"${port}" json:"port"`
+}
+
+func (c *JSONDB) UnmarshalJSON(b []byte) error {
+	type plain JSONDB
+	return json.Unmarshal(b, (*plain)(c))
+}
+
+func TestUnmarshaler(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		var s struct {
+			Color   Color               `value:"${color}"`
+			Colors  []Color             `value:"${colors}"`
+			Palette map[string]Color    `value:"${palette}"`
+			PColor  *Color              `value:"${color}"`
+			Level   Level               `value:"${level}"`
+			JLevel  Level               `value:"${json-level}"`
+			Primary Endpoint            `value:"${endpoints.primary}"`
+			Backup  *Endpoint           `value:"${endpoints.backup}"`
+			Others  map[string]Endpoint `value:"${others}"`
+		}
+		p := flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"color":      "red",
+			"colors":     "red,green",
+			"palette":    map[string]any{"bg": "green"},
+			"level":      "debug",
+			"json-level": `"info"`,
+			"endpoints": map[string]any{
+				"primary": map[string]any{
+					"host":  "a.example.com",
+					"port":  "8080",
+					"hosts": []any{"x", "y"},
+				},
+				"backup": "b.example.com:9090",
+			},
+			"others": map[string]any{
+				"c": map[string]any{"host": "c.example.com"},
+			},
+		}))
+		err := conf.Bind(p, &s)
+		assert.That(t, err).Nil()
+		assert.That(t, s.Color).Equal(Color(1))
+		assert.That(t, s.Colors).Equal([]Color{1, 2})
+		assert.That(t, s.Palette).Equal(map[string]Color{"bg": 2})
+		assert.That(t, *s.PColor).Equal(Color(1))
+		assert.That(t, s.Level.Name).Equal("debug")
+		assert.That(t, s.JLevel.Name).Equal("info")
+		assert.That(t, s.Primary).Equal(Endpoint{Host: "a.example.com", Port: 8080, Hosts: []string{"x", "y"}})
+		assert.That(t, *s.Backup).Equal(Endpoint{Host: "b.example.com", Port: 9090})
+		assert.That(t, s.Others).Equal(map[string]Endpoint{"c": {Host: "c.example.com", Port: 80, Hosts: []string{}}})
+	})
+
+	t.Run("text error", func(t *testing.T) {
+		var s struct {
+			Color Color `value:"${color:=blue}"`
+		}
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.NewProperties(nil)), &s)
+		assert.Error(t, err).Matches(`bind path=.*Color type=conf_test.Color error: unknown color "blue"`)
+	})
+
+	t.Run("missing property", func(t *testing.T) {
+		var s struct {
+			Level Level `value:"${level}"`
+		}
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.NewProperties(nil)), &s)
+		assert.Error(t, err).Matches(`property "level" not exist`)
+	})
+
+	t.Run("struct-shaped key", func(t *testing.T) {
+		var s struct {
+			DB    JSONDB  `value:"${db}"`
+			PDB   *JSONDB `value:"${db}"`
+			JDB   JSONDB  `value:"${json-db}"`
+			Level Level   `value:"${level:=\"warn\"}"`
+		}
+		p := flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"db":      map[string]any{"host": "h", "port": "1"},
+			"json-db": `{"host":"j","port":2}`,
+		}))
+		err := conf.Bind(p, &s)
+		assert.That(t, err).Nil()
+		assert.That(t, s.DB).Equal(JSONDB{Host: "h", Port: 1})
+		assert.That(t, *s.PDB).Equal(JSONDB{Host: "h", Port: 1})
+		assert.That(t, s.JDB).Equal(JSONDB{Host: "j", Port: 2})
+		assert.That(t, s.Level.Name).Equal("warn")
+	})
+
+	t.Run("unmarshal properties error", func(t *testing.T) {
+		var s struct {
+			Primary Endpoint `value:"${endpoints.primary}"`
+		}
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.NewProperties(nil)), &s)
+		assert.Error(t, err).Matches(`property "host" not exist`)
+	})
+}
+
+func TestBindOptional(t *testing.T) {
+
+	type TLSConfig struct {
+		Cert string `value:"${cert}"`
+		Key  string `value:"${key}"`
+	}
+
+	type Extra struct {
+		Debug bool `value:"${debug:=false}"`
+	}
+
+	type ServerConfig struct {
+		*Extra
+		Port    *int              `value:"${port}"`
+		Timeout *time.Duration    `value:"${timeout:=5s}"`
+		Name    *string           `value:"${name:=}"`
+		TLS     *TLSConfig        `value:"${tls}"`
+		Addrs   [3]string         `value:"${addrs:=}"`
+		Weights [2]int            `value:"${weights:=1,2}"`
+		Raw     any               `value:"${raw}"`
+		Labels  map[string]any    `value:"${labels:=}"`
+		Opt     any               `value:"${opt:=}"`
+		Levels  []*TLSConfig      `value:"${levels:=}"`
+		Exprs   *int              `value:"${exprs:=3}" expr:"$ > 2"`
+		Pointer map[string]*int   `value:"${pointers:=}"`
+		Fixed   [2]TLSConfig      `value:"${fixed:=}"`
+		Unused  map[string]string `value:"${unused:=}"`
+	}
+
+	t.Run("absent", func(t *testing.T) {
+		var s ServerConfig
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"raw": "x",
+		})), &s)
+		assert.That(t, err).Nil()
+		assert.That(t, s.Extra.Debug).False()
+		assert.That(t, s.Port).Nil()
+		assert.That(t, *s.Timeout).Equal(5 * time.Second)
+		assert.That(t, s.Name).Nil()
+		assert.That(t, s.TLS).Nil()
+		assert.That(t, s.Addrs).Equal([3]string{})
+		assert.That(t, s.Weights).Equal([2]int{1, 2})
+		assert.That(t, s.Raw).Equal("x")
+		assert.That(t, s.Opt).Nil()
+		assert.That(t, *s.Exprs).Equal(3)
+	})
+
+	t.Run("present", func(t *testing.T) {
+		var s ServerConfig
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"debug":   "true",
+			"port":    "8080",
+			"timeout": "1s",
+			"name":    "api",
+			"tls": map[string]any{
+				"cert": "a.crt",
+				"key":  "a.key",
+			},
+			"addrs": []any{"a", "b"},
+			"raw": map[string]any{
+				"host":  "${name}.local",
+				"ports": []any{"80", map[string]any{"tls": "443"}},
+			},
+			"labels":   map[string]any{"app": "api"},
+			"levels":   []any{map[string]any{"cert": "b.crt", "key": "b.key"}},
+			"pointers": map[string]any{"a": "1"},
+			"fixed":    []any{map[string]any{"cert": "c.crt", "key": "c.key"}},
+		})), &s)
+		assert.That(t, err).Nil()
+		assert.That(t, s.Extra.Debug).True()
+		assert.That(t, *s.Port).Equal(8080)
+		assert.That(t, *s.Timeout).Equal(time.Second)
+		assert.That(t, *s.Name).Equal("api")
+		assert.That(t, *s.TLS).Equal(TLSConfig{Cert: "a.crt", Key: "a.key"})
+		assert.That(t, s.Addrs).Equal([3]string{"a", "b", ""})
+		assert.That(t, s.Raw).Equal(map[string]any{
+			"host":  "api.local",
+			"ports": []any{"80", map[string]any{"tls": "443"}},
+		})
+		assert.That(t, s.Labels).Equal(map[string]any{"app": "api"})
+		assert.That(t, *s.Levels[0]).Equal(TLSConfig{Cert: "b.crt", Key: "b.key"})
+		assert.That(t, *s.Pointer["a"]).Equal(1)
+		assert.That(t, s.Fixed[0]).Equal(TLSConfig{Cert: "c.crt", Key: "c.key"})
+	})
+
+	t.Run("missing in present block", func(t *testing.T) {
+		var s ServerConfig
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"raw": "x",
+			"tls": map[string]any{"cert": "a.crt"},
+		})), &s)
+		assert.Error(t, err).Matches(`property "tls.key" not exist`)
+	})
+
+	t.Run("missing any", func(t *testing.T) {
+		var s ServerConfig
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.NewProperties(nil)), &s)
+		assert.Error(t, err).Matches(`bind path=ServerConfig.Raw type=interface {} error: property "raw" not exist`)
+	})
+
+	t.Run("pointer validate error", func(t *testing.T) {
+		var s ServerConfig
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"raw":   "x",
+			"exprs": "1",
+		})), &s)
+		assert.Error(t, err).Matches(`validate failed on "\$ > 2" for value 1`)
+	})
+
+	t.Run("reset to nil", func(t *testing.T) {
+		s := ServerConfig{Port: new(int)}
+		err := conf.Bind(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"raw": "x",
+		})), &s)
+		assert.That(t, err).Nil()
+		assert.That(t, s.Port).Nil()
+	})
+}
+
+type SelfEmbedded struct {
+	*SelfEmbedded
+	Name string `value:"${name:=a}"`
+}
+
+func TestBindSelfEmbedded(t *testing.T) {
+	var s SelfEmbedded
+	err := conf.Bind(flatten.NewPropertiesStorage(flatten.NewProperties(nil)), &s)
+	assert.That(t, err).Nil()
+	assert.That(t, s.SelfEmbedded).Nil()
+	assert.That(t, s.Name).Equal("a")
+}
+
+func TestBindAll(t *testing.T) {
+
+	type DBConfig struct {
+		Host string `value:"${host}"`
+		Port int    `value:"${port}" expr:"$ > 0"`
+	}
+
+	type Config struct {
+		Name    string            `value:"${name}"`
+		Timeout time.Duration     `value:"${timeout}"`
+		DB      DBConfig          `value:"${db}"`
+		Ratios  []float64         `value:"${ratios}"`
+		Limits  map[string]int    `value:"${limits}"`
+		Bad     int               `value:"bad"`
+		Labels  map[string]string `value:"${labels:=}"`
+	}
+
+	p := flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+		"timeout": "10x",
+		"db":      map[string]any{"port": "-1"},
+		"ratios":  "0.5,abc",
+		"limits":  map[string]any{"a": "1", "b": "x"},
+	}))
+
+	t.Run("fail fast", func(t *testing.T) {
+		var c Config
+		err := conf.Bind(p, &c)
+		assert.Error(t, err).Matches(`property "name" not exist`)
+		assert.That(t, errors.As(err, new(*conf.BindErrors))).False()
+	})
+
+	t.Run("all errors", func(t *testing.T) {
+		var c Config
+		err := conf.BindAll(p, &c)
+		var errs *conf.BindErrors
+		assert.That(t, errors.As(err, &errs)).True()
+
+		var arr [][4]string
+		for _, e := range errs.Errors {
+			arr = append(arr, [4]string{e.Path, e.Key, e.Value, e.Type})
+		}
+		assert.That(t, arr).Equal([][4]string{
+			{"Config.Name", "name", "", "string"},
+			{"Config.Timeout", "timeout", "10x", "time.Duration"},
+			{"Config.DB.Host", "db.host", "", "string"},
+			{"Config.DB.Port", "db.port", "-1", "int"},
+			{"Config.Ratios[1]", "ratios[1]", "abc", "float64"},
+			{"Config.Limits", "limits.b", "x", "int"},
+			{"Config.Bad", "", "bad", "int"},
+		})
+		assert.Error(t, errs.Errors[1]).Matches(`time: unknown unit "x" in duration "10x"`)
+		assert.Error(t, errs.Errors[3]).Matches(`validate failed on "\$ > 0" for value -1`)
+		assert.Error(t, err).Matches(`property "name" not exist; .*; .*invalid syntax tag 'bad'`)
+		assert.That(t, errors.Is(err, errs.Errors[0].Err)).True()
+
+		// the valid values are bound nevertheless
+		assert.That(t, c.Ratios).Equal([]float64{0.5, 0})
+		assert.That(t, c.Limits["a"]).Equal(1)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		var c DBConfig
+		err := conf.BindAll(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"host": "localhost",
+			"port": "3306",
+		})), &c)
+		assert.That(t, err).Nil()
+		assert.That(t, c).Equal(DBConfig{Host: "localhost", Port: 3306})
+	})
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-spring/stdlib/errutil"
+)
+
+// ByteSize is a number of bytes that binds from human-readable sizes,
+// e.g. "512", "10KB", "1.5MB" or "1GiB". As in Spring Boot, the units
+// KB, MB, GB, TB and PB are powers of 1024, the same as KiB, MiB, etc.
+type ByteSize int64
+
+// Units of ByteSize.
+const (
+	Byte ByteSize = 1 << (10 * iota)
+	KB
+	MB
+	GB
+	TB
+	PB
+)
+
+var byteSizeUnits = map[string]ByteSize{
+	"":  Byte,
+	"B": Byte,
+	"K": KB, "KB": KB, "KIB": KB,
+	"M": MB, "MB": MB, "MIB": MB,
+	"G": GB, "GB": GB, "GIB": GB,
+	"T": TB, "TB": TB, "TIB": TB,
+	"P": PB, "PB": PB, "PIB": PB,
+}
+
+// ParseByteSize parses a human-readable size, e.g. "10MB" or "1GiB".
+// Units are case-insensitive and may be separated from the number
+// by spaces.
+func ParseByteSize(s string) (ByteSize, error) {
+	str := strings.TrimSpace(s)
+	i := strings.IndexFunc(str, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(str)
+	}
+	num, unit := str[:i], strings.ToUpper(strings.TrimSpace(str[i:]))
+	m, ok := byteSizeUnits[unit]
+	if !ok || num == "" {
+		return 0, errutil.Explain(nil, "invalid byte size %q", s)
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, errutil.Explain(nil, "invalid byte size %q", s)
+	}
+	f *= float64(m)
+	if f >= math.MaxInt64 {
+		return 0, errutil.Explain(nil, "byte size %q out of range", s)
+	}
+	return ByteSize(f), nil
+}
+
+// String returns the size with the largest unit that represents it
+// exactly, e.g. "10MB" or "1536B".
+func (b ByteSize) String() string {
+	for _, u := range []struct {
+		size ByteSize
+		name string
+	}{{PB, "PB"}, {TB, "TB"}, {GB, "GB"}, {MB, "MB"}, {KB, "KB"}} {
+		if b != 0 && b%u.size == 0 {
+			return strconv.FormatInt(int64(b/u.size), 10) + u.name
+		}
+	}
+	return strconv.FormatInt(int64(b), 10) + "B"
+}
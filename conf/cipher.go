@@ -0,0 +1,215 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/go-spring/stdlib/errutil"
+)
+
+// DefaultDecryptor is the name of the decryptor used for encrypted
+// values that don't name one, e.g. "{cipher}BASE64" or "ENC(BASE64)".
+const DefaultDecryptor = "aes-gcm"
+
+// Environment variables that hold the key of the built-in AES-GCM decryptor,
+// either base64 encoded or as the path of a file containing the key.
+const (
+	CipherKeyEnv     = "SPRING_CIPHER_KEY"
+	CipherKeyFileEnv = "SPRING_CIPHER_KEY_FILE"
+)
+
+var decryptors = map[string]Decryptor{}
+
+// builtinDecryptors holds the names of the built-in decryptors that
+// RegisterDecryptor hasn't replaced yet.
+var builtinDecryptors = map[string]struct{}{}
+
+func init() {
+	RegisterDecryptor(DefaultDecryptor, envAESGCMDecryptor())
+	builtinDecryptors[DefaultDecryptor] = struct{}{}
+}
+
+// Decryptor decrypts the payload of an encrypted property value.
+type Decryptor func(ciphertext string) (string, error)
+
+// RegisterDecryptor registers a Decryptor with a specific name. It
+// replaces the built-in decryptor of the name, if any, e.g. "aes-gcm"
+// to read its key from elsewhere; registering a second decryptor with
+// the name panics.
+// Must be called in init functions only.
+func RegisterDecryptor(name string, fn Decryptor) {
+	if name == "" {
+		panic("decryptor name can't be empty")
+	}
+	if _, ok := decryptors[name]; ok {
+		if _, ok = builtinDecryptors[name]; !ok {
+			panic("decryptor " + name + " already exists")
+		}
+		delete(builtinDecryptors, name)
+	}
+	decryptors[name] = fn
+}
+
+// parseEncrypted reports whether a property value is encrypted and, if so,
+// returns the decryptor name and the payload. Supported forms are:
+//
+//	{cipher}PAYLOAD         -> DefaultDecryptor
+//	{cipher:name}PAYLOAD    -> named decryptor
+//	ENC(PAYLOAD)            -> DefaultDecryptor
+//	ENC(name:PAYLOAD)       -> named decryptor
+func parseEncrypted(s string) (name, payload string, ok bool) {
+	if str, found := strings.CutPrefix(s, "{cipher"); found {
+		i := strings.IndexByte(str, '}')
+		if i < 0 || (i > 0 && str[0] != ':') {
+			return "", "", false
+		}
+		name = DefaultDecryptor
+		if i > 0 {
+			name = str[1:i]
+		}
+		return name, str[i+1:], true
+	}
+	if str, found := strings.CutPrefix(s, "ENC("); found && strings.HasSuffix(str, ")") {
+		str = str[:len(str)-1]
+		if name, payload, found = strings.Cut(str, ":"); found {
+			return name, payload, true
+		}
+		return DefaultDecryptor, str, true
+	}
+	return "", "", false
+}
+
+// decrypt returns the plaintext of an encrypted value,
+// or the value itself if it is not encrypted.
+func decrypt(s string) (string, error) {
+	name, payload, ok := parseEncrypted(s)
+	if !ok {
+		return s, nil
+	}
+	fn, ok := decryptors[name]
+	if !ok {
+		return "", errutil.Explain(nil, "decryptor %q not found", name)
+	}
+	str, err := fn(payload)
+	if err != nil {
+		return "", errutil.Explain(err, "decrypt with %q error", name)
+	}
+	return str, nil
+}
+
+// NewAESGCMDecryptor returns a Decryptor for payloads produced by EncryptAESGCM.
+// The key must be 16, 24 or 32 bytes long.
+func NewAESGCMDecryptor(key []byte) (Decryptor, error) {
+	aead, err := newAESGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return func(ciphertext string) (string, error) {
+		b, err := base64.StdEncoding.DecodeString(ciphertext)
+		if err != nil {
+			return "", err
+		}
+		n := aead.NonceSize()
+		if len(b) < n {
+			return "", errutil.Explain(nil, "ciphertext too short")
+		}
+		b, err = aead.Open(nil, b[:n], b[n:], nil)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}, nil
+}
+
+// EncryptAESGCM encrypts a plaintext with AES-GCM and returns the base64
+// encoded nonce and ciphertext, to be used as "{cipher}" + result.
+func EncryptAESGCM(key []byte, plaintext string) (string, error) {
+	aead, err := newAESGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+	b := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// newAESGCM creates an AES-GCM cipher from the key.
+func newAESGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// envAESGCMDecryptor returns the built-in AES-GCM Decryptor. Its key is
+// read on first successful use from CipherKeyEnv, or else from the file
+// named by CipherKeyFileEnv, so that applications without encrypted values
+// don't need a key.
+func envAESGCMDecryptor() Decryptor {
+	var (
+		mu sync.Mutex
+		fn Decryptor
+	)
+	return func(ciphertext string) (string, error) {
+		mu.Lock()
+		if fn == nil {
+			key, err := loadCipherKey()
+			if err == nil {
+				fn, err = NewAESGCMDecryptor(key)
+			}
+			if err != nil {
+				mu.Unlock()
+				return "", err
+			}
+		}
+		f := fn
+		mu.Unlock()
+		return f(ciphertext)
+	}
+}
+
+// loadCipherKey loads the base64 encoded key of the built-in decryptor.
+func loadCipherKey() ([]byte, error) {
+	s, ok := os.LookupEnv(CipherKeyEnv)
+	if !ok {
+		file, ok := os.LookupEnv(CipherKeyFileEnv)
+		if !ok {
+			return nil, errutil.Explain(nil, "cipher key not found, set %s or %s", CipherKeyEnv, CipherKeyFileEnv)
+		}
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errutil.Explain(err, "read cipher key file error")
+		}
+		s = string(b)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, errutil.Explain(err, "decode cipher key error")
+	}
+	return key, nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf_test
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/stdlib/flatten"
+	"github.com/go-spring/stdlib/testing/assert"
+)
+
+func TestDecrypt(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	secret, err := conf.EncryptAESGCM(key, "p@ss${word}")
+	assert.That(t, err).Nil()
+
+	conf.RegisterDecryptor("upper", func(s string) (string, error) {
+		return strings.ToUpper(s), nil
+	})
+
+	keyFile := filepath.Join(t.TempDir(), "cipher.key")
+	err = os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600)
+	assert.That(t, err).Nil()
+	t.Setenv(conf.CipherKeyFileEnv, keyFile)
+
+	p := flatten.NewPropertiesStorage(flatten.NewProperties(map[string]string{
+		"db.password": "{cipher}" + secret,
+		"db.token":    "ENC(" + secret + ")",
+		"db.user":     "{cipher:upper}root",
+		"db.name":     "ENC(upper:demo)",
+		"db.bad":      "{cipher}bad",
+		"db.unknown":  "ENC(unknown:x)",
+	}))
+
+	t.Run("resolve", func(t *testing.T) {
+		s, err := conf.Resolve(p, "${db.user}:${db.password}@${db.name}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("ROOT:p@ss${word}@DEMO")
+	})
+
+	t.Run("resolve value", func(t *testing.T) {
+		assert.That(t, conf.IsEncrypted("ENC(upper:demo)")).True()
+		assert.That(t, conf.IsEncrypted("${db.name}")).False()
+
+		s, err := conf.ResolveValue(p, "db.name", "ENC(upper:demo)")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("DEMO")
+
+		s, err = conf.ResolveValue(p, "db.dsn", "${db.user}@${db.host:=localhost}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("ROOT@localhost")
+	})
+
+	t.Run("bind", func(t *testing.T) {
+		var c struct {
+			Password string `value:"${password}"`
+			Token    string `value:"${token}"`
+			Default  string `value:"${missing:={cipher:upper}def}"`
+		}
+		err := conf.Bind(p, &c, "${db}")
+		assert.That(t, err).Nil()
+		assert.That(t, c.Password).Equal("p@ss${word}")
+		assert.That(t, c.Token).Equal("p@ss${word}")
+		assert.That(t, c.Default).Equal("DEF")
+	})
+
+	t.Run("decrypt error", func(t *testing.T) {
+		var c struct {
+			Bad string `value:"${db.bad}"`
+		}
+		err := conf.Bind(p, &c)
+		assert.Error(t, err).Matches(`bind path=.*Bad type=string error: property "db.bad": decrypt with "aes-gcm" error`)
+	})
+
+	t.Run("unknown decryptor", func(t *testing.T) {
+		_, err := conf.Resolve(p, "${db.unknown}")
+		assert.Error(t, err).Matches(`property "db.unknown": decryptor "unknown" not found`)
+	})
+
+	t.Run("replace built-in", func(t *testing.T) {
+		t.Cleanup(conf.SaveDecryptor(conf.DefaultDecryptor))
+		conf.RegisterDecryptor("aes-gcm", func(s string) (string, error) {
+			return "plain-" + s, nil
+		})
+		assert.Panic(t, func() {
+			conf.RegisterDecryptor("aes-gcm", func(s string) (string, error) { return s, nil })
+		}, "decryptor aes-gcm already exists")
+		s, err := conf.Resolve(p, "${db.bad}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("plain-bad")
+	})
+}
@@ -17,7 +17,13 @@
 package conf
 
 import (
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,25 +36,85 @@ import (
 
 var converters = map[reflect.Type]any{}
 
+// builtinConverters holds the types of the built-in converters that
+// RegisterConverter hasn't replaced yet.
+var builtinConverters = map[reflect.Type]struct{}{}
+
 func init() {
-	RegisterConverter(func(s string) (time.Time, error) { return cast.ToTimeE(s) })
-	RegisterConverter(func(s string) (time.Duration, error) { return time.ParseDuration(s) })
+	registerBuiltin(func(s string) (time.Time, error) { return cast.ToTimeE(s) })
+	registerBuiltin(func(s string) (time.Duration, error) { return time.ParseDuration(s) })
+	registerBuiltin(func(s string) (*time.Location, error) { return time.LoadLocation(s) })
+	registerBuiltin(func(s string) (*url.URL, error) { return url.Parse(s) })
+	registerBuiltin(func(s string) (*regexp.Regexp, error) { return regexp.Compile(s) })
+	registerBuiltin(func(s string) (netip.Addr, error) { return netip.ParseAddr(s) })
+	registerBuiltin(func(s string) (netip.Prefix, error) { return netip.ParsePrefix(s) })
+	registerBuiltin(ParseByteSize)
+	registerBuiltin(parseIP)
+	registerBuiltin(parseIPNet)
+	registerBuiltin(parseFileMode)
+}
+
+// registerBuiltin registers a built-in Converter, which can be replaced
+// by RegisterConverter.
+func registerBuiltin[T any](fn Converter[T]) {
+	RegisterConverter(fn)
+	builtinConverters[reflect.TypeFor[T]()] = struct{}{}
+}
+
+// parseIP parses an IPv4 or IPv6 address.
+func parseIP(s string) (net.IP, error) {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, errutil.Explain(nil, "invalid IP address %q", s)
+	}
+	return ip, nil
+}
+
+// parseIPNet parses a CIDR, e.g. "192.168.0.0/16".
+func parseIPNet(s string) (*net.IPNet, error) {
+	_, ipNet, err := net.ParseCIDR(s)
+	return ipNet, err
+}
+
+// parseFileMode parses the permission bits of a file mode
+// in octal, e.g. "0644" or "755".
+func parseFileMode(s string) (os.FileMode, error) {
+	u, err := strconv.ParseUint(s, 8, 32)
+	if err != nil {
+		return 0, errutil.Explain(err, "invalid file mode %q", s)
+	}
+	return os.FileMode(u), nil
 }
 
 // Converter converts a string to a target type T.
 type Converter[T any] func(string) (T, error)
 
 // RegisterConverter registers a Converter for a type T, such as
-// time.Time, time.Duration, or other user-defined types.
+// time.Time, time.Duration, or other user-defined types. It replaces
+// the built-in converter of T, if any, e.g. for *url.URL or net.IP, so
+// that converters registered before these types were built in keep
+// working; registering a second converter for T panics.
 // Must be called in init functions only.
 func RegisterConverter[T any](fn Converter[T]) {
 	t := reflect.TypeFor[T]()
 	if _, ok := converters[t]; ok {
-		panic("converter for type " + t.String() + " already exists")
+		if _, ok = builtinConverters[t]; !ok {
+			panic("converter for type " + t.String() + " already exists")
+		}
+		delete(builtinConverters, t)
 	}
 	converters[t] = fn
 }
 
+// OverrideConverter registers a Converter for a type T, replacing the
+// existing one, if any. Use it to change a built-in converter deliberately.
+// Must be called in init functions only.
+func OverrideConverter[T any](fn Converter[T]) {
+	t := reflect.TypeFor[T]()
+	delete(builtinConverters, t)
+	converters[t] = fn
+}
+
 // RegisterReader registers its Reader for some kind of file extension.
 // Must be called in init functions only.
 func RegisterReader(r reader.Reader, ext ...string) {
@@ -61,6 +127,13 @@ func RegisterProvider(name string, p provider.Provider) {
 	provider.Register(name, p)
 }
 
+// RegisterWatcher registers a Watcher for a specific configuration source,
+// so that changes of the source can be reported.
+// Must be called in init functions only.
+func RegisterWatcher(name string, w provider.Watcher) {
+	provider.RegisterWatcher(name, w)
+}
+
 // Load creates a Properties instance from a configuration source.
 // The source format is [optional:]<provider>:<path> or just <path>.
 // Returns an error if the file type is not supported or parsing fails.
@@ -78,6 +151,25 @@ func Load(source string) (*flatten.Properties, error) {
 // Supports default values: ${key:=default}.
 // If no tag is provided, uses ${ROOT} (binds from the root).
 func Bind(p flatten.Storage, i any, tag ...string) error {
+	return bind(p, i, nil, tag...)
+}
+
+// BindAll is like Bind, but it walks the whole target instead of stopping
+// at the first failure. Every missing key, conversion failure and expr
+// validation failure is returned at once in a *BindErrors.
+func BindAll(p flatten.Storage, i any, tag ...string) error {
+	errs := &BindErrors{}
+	if err := bind(p, i, errs, tag...); err != nil {
+		return err
+	}
+	if errs.Len() > 0 {
+		return errs
+	}
+	return nil
+}
+
+// bind binds the target, collecting errors into errs if it is not nil.
+func bind(p flatten.Storage, i any, errs *BindErrors, tag ...string) error {
 
 	var v reflect.Value
 	{
@@ -110,6 +202,7 @@ func Bind(p flatten.Storage, i any, tag ...string) error {
 		return errutil.Explain(err, "bind tag '%s' error", s)
 	}
 	param.Path = typeName
+	param.Errors = errs
 	return BindValue(p, v, t, param, nil)
 }
 
@@ -119,6 +212,8 @@ func Bind(p flatten.Storage, i any, tag ...string) error {
 // Supported features:
 // - Nested references: e.g. "${outer${inner}}"
 // - Default values:    "${key:=fallback}"
+// - Functions:         "${random.uuid}", "${env(HOME, /tmp)}", "${file:/run/secrets/x}"
+// - Escaping:          "$${literal}" gives "${literal}"
 // - Arbitrary string concatenation around references.
 //
 // Example:
@@ -133,7 +228,28 @@ func Bind(p flatten.Storage, i any, tag ...string) error {
 //
 // Errors:
 // - Returns invalid syntax if braces are unbalanced.
+// - Returns the chain of a circular reference, e.g. "a -> b -> a".
 func Resolve(p flatten.Storage, s string) (string, error) {
+	return resolveString(p, s, nil)
+}
+
+// ResolveValue returns the effective value of a property, as binding
+// sees it: the plaintext of an encrypted value, or otherwise the value
+// with its references resolved.
+func ResolveValue(p flatten.Storage, key string, val string) (string, error) {
+	return resolveValue(p, key, val, []string{key})
+}
+
+// IsEncrypted reports whether a property value is encrypted,
+// e.g. "{cipher}BASE64" or "ENC(BASE64)".
+func IsEncrypted(val string) bool {
+	_, _, ok := parseEncrypted(val)
+	return ok
+}
+
+// resolveString resolves the references in s. The chain holds the keys
+// being resolved, whose values s is part of.
+func resolveString(p flatten.Storage, s string, chain []string) (string, error) {
 
 	// If there is no property reference, return the original string.
 	start := strings.Index(s, "${")
@@ -141,6 +257,15 @@ func Resolve(p flatten.Storage, s string) (string, error) {
 		return s, nil
 	}
 
+	// "$${" is an escaped "${", taken literally.
+	if start > 0 && s[start-1] == '$' {
+		suffix, err := resolveString(p, s[start+2:], chain)
+		if err != nil {
+			return "", err
+		}
+		return s[:start-1] + "${" + suffix, nil
+	}
+
 	var (
 		level = 1
 		end   = -1
@@ -172,13 +297,13 @@ func Resolve(p flatten.Storage, s string) (string, error) {
 	}
 
 	// resolve the referenced property
-	resolved, err := resolve(p, param)
+	resolved, err := resolve(p, param, chain)
 	if err != nil {
 		return "", errutil.Explain(err, "resolve string %q error", s)
 	}
 
 	// resolve the remaining part of the string
-	suffix, err := Resolve(p, s[end+1:])
+	suffix, err := resolveString(p, s[end+1:], chain)
 	if err != nil {
 		return "", errutil.Explain(err, "resolve string %q error", s)
 	}
@@ -41,14 +41,67 @@ func TestProperties_Load(t *testing.T) {
 		})
 	})
 
+	t.Run("dotenv", func(t *testing.T) {
+		p, err := conf.Load("file:./testdata/config/app.env")
+		assert.That(t, err).Nil()
+		assert.That(t, p.Data()).Equal(map[string]string{
+			"dotenv.port": "8080",
+			"dotenv.host": "localhost",
+			"dotenv.url":  "http://localhost:8080",
+		})
+	})
+
+	t.Run("ini", func(t *testing.T) {
+		p, err := conf.Load("./testdata/config/app.ini")
+		assert.That(t, err).Nil()
+		var c struct {
+			Name  string   `value:"${name}"`
+			Addrs []string `value:"${server.addr}"`
+		}
+		err = conf.Bind(flatten.NewPropertiesStorage(p), &c, "${ini}")
+		assert.That(t, err).Nil()
+		assert.That(t, c.Name).Equal("demo")
+		assert.That(t, c.Addrs).Equal([]string{"127.0.0.1:8080", "127.0.0.1:9090"})
+	})
+
 	t.Run("file not exist", func(t *testing.T) {
 		_, err := conf.Load("./testdata/config/xxx.yml")
 		assert.Error(t, err).Matches("no such file or directory")
 	})
 
-	t.Run("unsupported ext", func(t *testing.T) {
-		_, err := conf.Load("./testdata/config/app.unknown")
-		assert.Error(t, err).Matches("unsupported file type .unknown")
+	t.Run("optional file not exist", func(t *testing.T) {
+		p, err := conf.Load("optional:file:./testdata/config/xxx.yml")
+		assert.That(t, err).Nil()
+		assert.That(t, p.Data()).Equal(map[string]string{})
+	})
+
+	t.Run("unknown ext", func(t *testing.T) {
+		p, err := conf.Load("./testdata/config/app.unknown")
+		assert.That(t, err).Nil()
+		assert.That(t, p.Data()).Equal(map[string]string{})
+	})
+
+	t.Run("detect format", func(t *testing.T) {
+		p, err := conf.Load("file:./testdata/config/application")
+		assert.That(t, err).Nil()
+		assert.That(t, p.Data()).Equal(map[string]string{
+			"application.name": "demo",
+			"application.port": "8080",
+		})
+	})
+
+	t.Run("explicit format", func(t *testing.T) {
+		p, err := conf.Load("file:./testdata/config/application?format=yaml")
+		assert.That(t, err).Nil()
+		assert.That(t, p.Data()).Equal(map[string]string{
+			"application.name": "demo",
+			"application.port": "8080",
+		})
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := conf.Load("file:./testdata/config/application?format=xml")
+		assert.Error(t, err).Matches("unsupported file type xml")
 	})
 
 	t.Run("syntax error", func(t *testing.T) {
@@ -114,6 +167,54 @@ func TestProperties_Resolve(t *testing.T) {
 	//	_, err := conf.Resolve(p,"${a.b.c[0]}==${a.b.c}")
 	//	assert.Error(t, err).Matches("property \"a.b.c\" isn't simple value")
 	//})
+
+	t.Run("circular reference", func(t *testing.T) {
+		p := flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"a": "${b}",
+			"b": "x-${c:=${a}}",
+			"d": "${d}",
+		}))
+		_, err := conf.Resolve(p, "${a}")
+		assert.Error(t, err).Matches("circular reference a -> b -> a")
+
+		_, err = conf.Resolve(p, "${d}")
+		assert.Error(t, err).Matches("circular reference d -> d")
+
+		var v struct {
+			A string `value:"${b}"`
+		}
+		err = conf.Bind(p, &v)
+		assert.Error(t, err).Matches("circular reference b -> a -> b")
+	})
+
+	t.Run("repeated reference", func(t *testing.T) {
+		p := flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"host": "localhost",
+			"url":  "${host}:${host}",
+		}))
+		s, err := conf.Resolve(p, "${url}/${host}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("localhost:localhost/localhost")
+	})
+
+	t.Run("escaped", func(t *testing.T) {
+		p := flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"host": "localhost",
+			"url":  "http://${host}/$${path}?q=$${query:=x}",
+			"copy": "${url}",
+		}))
+		s, err := conf.Resolve(p, "${copy}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("http://localhost/${path}?q=${query:=x}")
+
+		s, err = conf.Resolve(p, "${missing:=$${x}}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("${x}")
+
+		s, err = conf.Resolve(p, "$$${host}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("$${host}")
+	})
 }
 
 func TestProperties_CopyTo(t *testing.T) {
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	"reflect"
+
+	"github.com/go-spring/stdlib/errutil"
+)
+
+// PropertyInfo describes a property that binding a type reads.
+// In the key, "*" stands for the keys of a map and "[*]" for the
+// indexes of a slice, e.g. "servers[*].host" or "users.*.age".
+type PropertyInfo struct {
+	Key      string       // property key
+	Type     reflect.Type // type the value is bound to
+	Default  string       // default value from ${key:=default}
+	HasDef   bool         // whether a default value is given
+	Expr     string       // expr validation tag
+	Validate string       // validate rules tag
+	Text     bool         // whether the value (or element) is parsed by a converter or an unmarshaler
+}
+
+// DescribeProperties returns the properties that binding a value of type t
+// with the tag, e.g. "${db}", reads, in field order. An empty tag binds the
+// fields of a struct from the root. The optional unwrap function maps a
+// wrapper type, such as a dynamic value, to the type it binds.
+func DescribeProperties(t reflect.Type, tag string, unwrap func(t reflect.Type) (reflect.Type, bool)) ([]PropertyInfo, error) {
+	d := &describer{
+		unwrap:   unwrap,
+		visiting: make(map[reflect.Type]bool),
+	}
+	param := BindParam{Path: t.String()}
+	if tag != "" {
+		if err := param.BindTag(tag, ""); err != nil {
+			return nil, err
+		}
+	}
+	if err := d.describe(t, param); err != nil {
+		return nil, err
+	}
+	return d.props, nil
+}
+
+// describer walks a type the same way BindValue binds it.
+type describer struct {
+	unwrap   func(t reflect.Type) (reflect.Type, bool)
+	visiting map[reflect.Type]bool
+	props    []PropertyInfo
+}
+
+// describe collects the properties of the type t bound with param.
+func (d *describer) describe(t reflect.Type, param BindParam) error {
+	if d.unwrap != nil {
+		if et, ok := d.unwrap(t); ok {
+			t = et
+		}
+	}
+
+	leaf := func() error {
+		et := t
+		if et.Kind() == reflect.Slice || et.Kind() == reflect.Array {
+			et = et.Elem()
+		}
+		for et.Kind() == reflect.Pointer {
+			et = et.Elem()
+		}
+		d.props = append(d.props, PropertyInfo{
+			Key:      param.Key,
+			Type:     t,
+			Default:  param.Tag.Def,
+			HasDef:   param.Tag.HasDef,
+			Expr:     param.Validate.Get("expr"),
+			Validate: param.Validate.Get("validate"),
+			Text:     converters[et] != nil || implementsUnmarshaler(et),
+		})
+		return nil
+	}
+
+	if converters[t] != nil || implementsUnmarshaler(t) || isAnyType(t) {
+		return leaf()
+	}
+	if !isPropBindingTarget(t) {
+		return errutil.Explain(nil, "describe path=%s type=%s error: target should be value type", param.Path, t.String())
+	}
+
+	// recursive types are described down to their first repetition.
+	if d.visiting[t] {
+		return nil
+	}
+	d.visiting[t] = true
+	defer delete(d.visiting, t)
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return d.describe(t.Elem(), param)
+	case reflect.Map:
+		subParam := BindParam{
+			Key:  joinDescribeKey(param.Key, "*"),
+			Path: param.Path,
+		}
+		return d.describe(t.Elem(), subParam)
+	case reflect.Slice, reflect.Array:
+		if isSimpleElem(t.Elem()) {
+			return leaf() // may also be written as "a,b,c"
+		}
+		subParam := BindParam{
+			Key:  param.Key + "[*]",
+			Path: param.Path + "[*]",
+		}
+		return d.describe(t.Elem(), subParam)
+	case reflect.Struct:
+		return d.describeStruct(t, param)
+	default:
+		return leaf()
+	}
+}
+
+// describeStruct collects the properties of the fields of struct t.
+func (d *describer) describeStruct(t reflect.Type, param BindParam) error {
+	for i := range t.NumField() {
+		ft := t.Field(i)
+		if !ft.IsExported() {
+			continue
+		}
+		subParam := BindParam{
+			Key:  param.Key,
+			Path: param.Path + "." + ft.Name,
+		}
+		if tag, ok := ft.Tag.Lookup("value"); ok {
+			if err := subParam.BindTag(tag, ft.Tag); err != nil {
+				return errutil.Explain(err, "describe path=%s type=%s error", param.Path, t.String())
+			}
+			if err := d.describe(ft.Type, subParam); err != nil {
+				return err // no wrap
+			}
+			continue
+		}
+		if ft.Anonymous {
+			et := ft.Type
+			if et.Kind() == reflect.Pointer {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct && !d.visiting[et] {
+				if err := d.describeStruct(et, subParam); err != nil {
+					return err // no wrap
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isSimpleElem reports whether slice elements of type t are single
+// values, so that the slice can also be bound from a delimited string.
+func isSimpleElem(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if converters[t] != nil || implementsUnmarshaler(t) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Interface:
+		return false
+	default:
+		return true
+	}
+}
+
+// joinDescribeKey appends a segment to a key.
+func joinDescribeKey(key, s string) string {
+	if key == "" {
+		return s
+	}
+	return key + "." + s
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/stdlib/testing/assert"
+)
+
+type DescribeServer struct {
+	Host    string        `value:"${host:=localhost}"`
+	Port    int           `value:"${port}" expr:"$ > 0"`
+	Timeout time.Duration `value:"${timeout:=5s}"`
+	Tags    []string      `value:"${tags:=}"`
+}
+
+type DescribeConfig struct {
+	DescribeServer `value:"${server}"`
+	Name           string                    `value:"${name}" validate:"required"`
+	Backends       []DescribeServer          `value:"${backends}"`
+	Users          map[string]int            `value:"${users}"`
+	Next           *DescribeConfig           `value:"${next}"`
+	Extra          map[string]DescribeServer `value:"${extra:=}"`
+}
+
+func TestDescribeProperties(t *testing.T) {
+
+	t.Run("struct", func(t *testing.T) {
+		props, err := conf.DescribeProperties(reflect.TypeFor[DescribeConfig](), "${app}", nil)
+		assert.That(t, err).Nil()
+		var keys []string
+		for _, p := range props {
+			keys = append(keys, p.Key)
+		}
+		assert.That(t, keys).Equal([]string{
+			"app.server.host",
+			"app.server.port",
+			"app.server.timeout",
+			"app.server.tags",
+			"app.name",
+			"app.backends[*].host",
+			"app.backends[*].port",
+			"app.backends[*].timeout",
+			"app.backends[*].tags",
+			"app.users.*",
+			"app.extra.*.host",
+			"app.extra.*.port",
+			"app.extra.*.timeout",
+			"app.extra.*.tags",
+		})
+
+		assert.That(t, props[0].Default).Equal("localhost")
+		assert.That(t, props[0].HasDef).True()
+		assert.That(t, props[1].HasDef).False()
+		assert.That(t, props[1].Expr).Equal("$ > 0")
+		assert.That(t, props[2].Type).Equal(reflect.TypeFor[time.Duration]())
+		assert.That(t, props[2].Text).True()
+		assert.That(t, props[3].Type).Equal(reflect.TypeFor[[]string]())
+		assert.That(t, props[4].Validate).Equal("required")
+	})
+
+	t.Run("unwrap", func(t *testing.T) {
+		type Wrapper struct{ v int }
+		var s struct {
+			A Wrapper `value:"${a}"`
+		}
+		props, err := conf.DescribeProperties(reflect.TypeOf(s), "", func(t reflect.Type) (reflect.Type, bool) {
+			if t == reflect.TypeFor[Wrapper]() {
+				return reflect.TypeFor[int](), true
+			}
+			return nil, false
+		})
+		assert.That(t, err).Nil()
+		assert.That(t, len(props)).Equal(1)
+		assert.That(t, props[0].Key).Equal("a")
+		assert.That(t, props[0].Type).Equal(reflect.TypeFor[int]())
+	})
+
+	t.Run("invalid tag", func(t *testing.T) {
+		var s struct {
+			A int `value:"a"`
+		}
+		_, err := conf.DescribeProperties(reflect.TypeOf(s), "", nil)
+		assert.Error(t, err).Matches("describe path=.* error: invalid syntax tag 'a'")
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		var s struct {
+			A chan int `value:"${a}"`
+		}
+		_, err := conf.DescribeProperties(reflect.TypeOf(s), "", nil)
+		assert.Error(t, err).Matches("describe path=.*\\.A type=chan int error: target should be value type")
+	})
+}
@@ -46,8 +46,25 @@ Supports binding to various types with automatic conversion:
   - Slices: From indexed properties
   - Maps: Via subkey expansion
   - Structs: Recursive binding of nested structures
+  - Arrays: Like slices, with a length check
+  - Pointers: Nil when the property is absent, for optional blocks
+  - any: The raw value, e.g. a string or a nested map[string]any
 
-3. Custom Types: Register converters using RegisterConverter
+3. Built-in Types: time.Time, time.Duration, *time.Location, *url.URL,
+net.IP, *net.IPNet, netip.Addr, netip.Prefix, *regexp.Regexp,
+os.FileMode (octal) and ByteSize (e.g. 10MB, 1GiB)
+
+4. Unmarshalers: Types implementing Unmarshaler bind themselves from the
+subtree under their key; encoding.TextUnmarshaler and json.Unmarshaler
+bind from the value of the key
+
+5. Custom Types: Register converters using RegisterConverter. A type
+with a built-in converter can be registered once, replacing the built-in
+one; OverrideConverter replaces any existing converter
+
+Bind stops at the first failure. BindAll walks the whole target and
+returns every missing key, conversion failure and validation failure
+at once as *BindErrors, each with its path, key, raw value and type.
 
 # Validation System:
 
@@ -63,6 +80,20 @@ Supports binding to various types with automatic conversion:
     .    return t.After(time.Now())
     })
 
+ 3. Declarative rules using validate tag:
+
+    type PoolConfig struct {
+    .    Name     string `value:"${name}" validate:"required,pattern=^[a-z]+$"`
+    .    MinConns int    `value:"${min-conns:=1}" validate:"min=1"`
+    .    MaxConns int    `value:"${max-conns:=10}" validate:"gtefield=MinConns"`
+    }
+
+The built-in rules are required, min, max, len, pattern, oneof, email,
+hostport and url; min and max compare lengths of strings and collections.
+Cross-field rules (eqfield, nefield, gtfield, gtefield, ltfield and
+ltefield) and expr tags may reference sibling fields by name, e.g.
+expr:"$ >= MinConns"; they run once the whole struct is bound.
+
 # File Support:
 
 Built-in readers handle:
@@ -70,14 +101,45 @@ Built-in readers handle:
 - Properties (.properties)
 - YAML (.yaml/.yml)
 - TOML (.toml/.tml)
+- Dotenv (.env)
+- HCL (.hcl)
+- INI (.ini/.cfg)
 
 Register custom readers with RegisterReader.
 
+YAML files may hold several "---" separated documents, merged in order.
+Documents gated by spring.config.activate.on-profile are skipped by the
+reader and applied by the application for the active profiles.
+
+Files without a known extension, such as mounted ConfigMaps, are parsed
+by sniffing their content. The format can also be forced in the source,
+e.g. "file:/etc/config/application?format=yaml".
+
 # Property Resolution:
 
 - Recursive ${} substitution
 - Type-aware defaults
 - Chained defaults (${A:=${B:=C}})
+- Escaping ($${literal} gives ${literal})
+- Circular references (a=${b}, b=${a}) reported as "a -> b -> a"
+- Encrypted values ({cipher}BASE64, ENC(BASE64)) via RegisterDecryptor
+- Functions (${random.uuid}, ${file:/run/secrets/x}) via RegisterPlaceholderFunc
+
+The built-in functions are env(NAME, fallback), random.uuid,
+random.int(min,max), hostname, pid, file:path, base64(s), upper(s) and
+lower(s), e.g. "${upper(${app.name})}" or "${env(HOME):=/tmp}".
+
+The built-in "aes-gcm" decryptor reads its base64 key from the
+SPRING_CIPHER_KEY environment variable, or from the file named by
+SPRING_CIPHER_KEY_FILE. Registering a decryptor named "aes-gcm" replaces it.
+
+Keys are bound relaxed: read-timeout, readTimeout, read_timeout and, for
+keys of several segments, the environment form SERVER_READ_TIMEOUT all
+resolve to the same property. The spellings are looked up layer by layer
+of a LayeredStorage, so the command line still wins over the environment.
+The environment form is only looked up in the environment layer, where it
+overrides the others; two other spellings with different values are
+reported as a conflict.
 
 # Extension Points:
 
@@ -85,6 +147,9 @@ Register custom readers with RegisterReader.
 2. RegisterConverter: Add type converters
 3. RegisterReader: Support new file formats
 4. RegisterValidateFunc: Add custom validators
+5. RegisterWatcher: Report changes of configuration sources
+6. RegisterDecryptor: Decrypt encrypted property values
+7. RegisterPlaceholderFunc: Add placeholder functions
 
 # Examples:
 
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	"reflect"
+	"strings"
+)
+
+// BindError describes the failure to bind a single value: a missing key,
+// a conversion failure or a validation failure.
+type BindError struct {
+	Path  string // path of the target, e.g. "Config.Server.Port"
+	Key   string // property key, e.g. "server.port"
+	Value string // raw property value, empty if the key is missing
+	Type  string // expected type, e.g. "int"
+	Err   error  // underlying error
+}
+
+// Error returns the message of the underlying error.
+func (e *BindError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// BindErrors collects every BindError of a binding that doesn't stop at the
+// first failure, see BindAll and BindParam.Errors.
+type BindErrors struct {
+	Errors []*BindError
+}
+
+// Len returns the number of errors, zero for a nil BindErrors.
+func (e *BindErrors) Len() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.Errors)
+}
+
+// Error concatenates all errors into a single string.
+func (e *BindErrors) Error() string {
+	var sb strings.Builder
+	for i, err := range e.Errors {
+		sb.WriteString(err.Error())
+		if i < len(e.Errors)-1 {
+			sb.WriteString("; ")
+		}
+	}
+	return sb.String()
+}
+
+// Unwrap returns the collected errors.
+func (e *BindErrors) Unwrap() []error {
+	ret := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		ret[i] = err
+	}
+	return ret
+}
+
+// collectError returns err, or, if param collects errors, records err as a
+// BindError and returns nil so that binding goes on with the next value.
+func collectError(param BindParam, t reflect.Type, val string, err error) error {
+	if param.Errors == nil {
+		return err
+	}
+	param.Errors.Errors = append(param.Errors.Errors, &BindError{
+		Path:  param.Path,
+		Key:   param.Key,
+		Value: val,
+		Type:  t.String(),
+		Err:   err,
+	})
+	return nil
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import "reflect"
+
+// SaveConverter saves the converter of the type T, and whether it is
+// built in, and returns a function that restores them.
+func SaveConverter[T any]() (restore func()) {
+	t := reflect.TypeFor[T]()
+	fn, ok := converters[t]
+	_, builtin := builtinConverters[t]
+	return func() {
+		delete(converters, t)
+		if ok {
+			converters[t] = fn
+		}
+		delete(builtinConverters, t)
+		if builtin {
+			builtinConverters[t] = struct{}{}
+		}
+	}
+}
+
+// SaveDecryptor saves the decryptor of the name, and whether it is
+// built in, and returns a function that restores them.
+func SaveDecryptor(name string) (restore func()) {
+	fn, ok := decryptors[name]
+	_, builtin := builtinDecryptors[name]
+	return func() {
+		delete(decryptors, name)
+		if ok {
+			decryptors[name] = fn
+		}
+		delete(builtinDecryptors, name)
+		if builtin {
+			builtinDecryptors[name] = struct{}{}
+		}
+	}
+}
@@ -18,8 +18,11 @@ package conf
 
 import (
 	"maps"
+	"slices"
 
 	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
 	"github.com/go-spring/stdlib/errutil"
 )
 
@@ -44,10 +47,12 @@ func RegisterValidateFunc[T any](name string, fn ValidateFunc[T]) {
 
 // validateField validates a field using a validation expression (tag) and the field value (i).
 // It evaluates the expression and checks if the result is true (i.e., the validation passes).
+// The sibling fields, if any, can be referenced by name, e.g. "$ >= MinConns".
 // If any error occurs during evaluation or if the validation fails, an error is returned.
-func validateField(tag string, i any) error {
+func validateField(tag string, i any, siblings map[string]any) error {
 	env := map[string]any{"$": i}
 	maps.Copy(env, validateFuncs)
+	maps.Copy(env, siblings)
 	r, err := expr.Eval(tag, env)
 	if err != nil {
 		return errutil.Explain(err, "eval %q returns error", tag)
@@ -61,3 +66,43 @@ func validateField(tag string, i any) error {
 	}
 	return nil
 }
+
+// exprNames returns the names referenced by a validation expression other
+// than "$" and the validation functions, i.e. the sibling fields. Such
+// expressions are evaluated once the whole struct is bound.
+func exprNames(tag string) []string {
+	tree, err := parser.Parse(tag)
+	if err != nil {
+		return nil // reported when evaluated
+	}
+	v := &identVisitor{declared: make(map[string]bool)}
+	ast.Walk(&tree.Node, v)
+	var ret []string
+	for _, name := range v.names {
+		if !v.declared[name] && !slices.Contains(ret, name) {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+// identVisitor collects the identifiers that name sibling fields.
+type identVisitor struct {
+	names    []string
+	declared map[string]bool
+}
+
+// Visit implements ast.Visitor.
+func (v *identVisitor) Visit(node *ast.Node) {
+	switch n := (*node).(type) {
+	case *ast.VariableDeclaratorNode:
+		v.declared[n.Name] = true
+	case *ast.IdentifierNode:
+		if n.Value == "$" || n.Value == "$env" {
+			return
+		}
+		if _, ok := validateFuncs[n.Value]; !ok {
+			v.names = append(v.names, n.Value)
+		}
+	}
+}
@@ -106,7 +106,7 @@ func TestExpr(t *testing.T) {
 			"a": 5,
 		}))
 		err := conf.Bind(p, &v)
-		assert.Error(t, err).Matches("eval .* returns error")
+		assert.Error(t, err).Matches(`validate expr "unknownFunc\(\$\)" error: unknown name "unknownFunc"`)
 	})
 
 	t.Run("empty expression", func(t *testing.T) {
@@ -0,0 +1,277 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-spring/stdlib/errutil"
+	"github.com/go-spring/stdlib/flatten"
+)
+
+// Placeholder functions compute the value of a placeholder instead of
+// looking up a property. They are called in one of three forms:
+//
+//	${upper(${app.name})}     name(arg, ...)
+//	${file:/run/secrets/db}   name:arg
+//	${random.uuid}            name, if no property has the name
+//
+// The bare form is only used for top-level placeholders, so that a
+// missing "server.hostname" isn't mistaken for the hostname function,
+// and falls back to the property lookup if the function fails.
+//
+// Arguments are trimmed and their placeholders resolved before the call.
+// If the function fails and the placeholder has a default value, e.g.
+// ${file:/run/secrets/db:=dev}, the default value is used.
+//
+// Built-in functions:
+//
+//	env(name[, fallback])   environment variable, or fallback if unset
+//	random.uuid             random UUID, e.g. "3b241101-e2bb-4255-8caf-4136c566a962"
+//	random.int[(min,max)]   random int in [min,max), or any non-negative int32
+//	hostname                host name of the machine
+//	pid                     process ID
+//	file:path               file contents, without trailing line breaks
+//	base64(s)               base64 decoding of s
+//	upper(s), lower(s)      s in upper or lower case
+//
+// Random values are generated each time the placeholder is resolved.
+
+// PlaceholderFunc computes the value of a placeholder from its arguments.
+type PlaceholderFunc func(args ...string) (string, error)
+
+var placeholderFuncs = map[string]PlaceholderFunc{}
+
+func init() {
+	RegisterPlaceholderFunc("env", placeholderEnv)
+	RegisterPlaceholderFunc("random.uuid", placeholderUUID)
+	RegisterPlaceholderFunc("random.int", placeholderRandomInt)
+	RegisterPlaceholderFunc("hostname", placeholderHostname)
+	RegisterPlaceholderFunc("pid", placeholderPID)
+	RegisterPlaceholderFunc("file", placeholderFile)
+	RegisterPlaceholderFunc("base64", placeholderBase64)
+	RegisterPlaceholderFunc("upper", placeholderUpper)
+	RegisterPlaceholderFunc("lower", placeholderLower)
+}
+
+// RegisterPlaceholderFunc registers a placeholder function with a specific
+// name, so that placeholders like ${name(arg)} are computed by it.
+// Must be called in init functions only.
+func RegisterPlaceholderFunc(name string, fn PlaceholderFunc) {
+	if name == "" {
+		panic("placeholder function name can't be empty")
+	}
+	if _, ok := placeholderFuncs[name]; ok {
+		panic("placeholder function " + name + " already exists")
+	}
+	placeholderFuncs[name] = fn
+}
+
+// parseCall parses the key of a placeholder as a function call.
+// It returns false if the key doesn't call a registered function.
+// A bare function name is reported with bare set to true.
+func parseCall(key string) (fn PlaceholderFunc, args []string, bare bool, ok bool) {
+	if i := strings.IndexByte(key, '('); i > 0 && strings.HasSuffix(key, ")") {
+		if fn, ok = placeholderFuncs[strings.TrimSpace(key[:i])]; ok {
+			return fn, splitArgs(key[i+1 : len(key)-1]), false, true
+		}
+		return nil, nil, false, false
+	}
+	if i := strings.IndexByte(key, ':'); i > 0 {
+		if fn, ok = placeholderFuncs[key[:i]]; ok {
+			return fn, []string{strings.TrimSpace(key[i+1:])}, false, true
+		}
+		return nil, nil, false, false
+	}
+	if fn, ok = placeholderFuncs[key]; ok {
+		return fn, nil, true, true
+	}
+	return nil, nil, false, false
+}
+
+// splitArgs splits the arguments of a call at the commas that are
+// not inside nested placeholders or calls.
+func splitArgs(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	var (
+		ret   []string
+		level int
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '{':
+			level++
+		case ')', '}':
+			level--
+		case ',':
+			if level == 0 {
+				ret = append(ret, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(ret, strings.TrimSpace(s[start:]))
+}
+
+// callPlaceholder resolves the arguments of a placeholder function
+// and calls it.
+func callPlaceholder(p flatten.Storage, key string, fn PlaceholderFunc, args []string, chain []string) (string, error) {
+	for i, arg := range args {
+		s, err := resolveString(p, arg, chain)
+		if err != nil {
+			return "", errutil.Explain(err, "placeholder %q error", key)
+		}
+		args[i] = s
+	}
+	s, err := fn(args...)
+	if err != nil {
+		return "", errutil.Explain(err, "placeholder %q error", key)
+	}
+	return s, nil
+}
+
+// checkArgs checks that the number of arguments is in [minArgs,maxArgs].
+func checkArgs(args []string, minArgs, maxArgs int) error {
+	if len(args) < minArgs || len(args) > maxArgs {
+		if minArgs == maxArgs {
+			return errutil.Explain(nil, "expects %d arguments but got %d", minArgs, len(args))
+		}
+		return errutil.Explain(nil, "expects %d to %d arguments but got %d", minArgs, maxArgs, len(args))
+	}
+	return nil
+}
+
+// placeholderEnv returns an environment variable, or the fallback if unset.
+func placeholderEnv(args ...string) (string, error) {
+	if err := checkArgs(args, 1, 2); err != nil {
+		return "", err
+	}
+	if s, ok := os.LookupEnv(args[0]); ok {
+		return s, nil
+	}
+	if len(args) > 1 {
+		return args[1], nil
+	}
+	return "", errutil.Explain(nil, "environment variable %q not set", args[0])
+}
+
+// placeholderUUID returns a random (version 4) UUID.
+func placeholderUUID(args ...string) (string, error) {
+	if err := checkArgs(args, 0, 0); err != nil {
+		return "", err
+	}
+	var b [16]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// placeholderRandomInt returns a random int in [min,max), in [0,max) for
+// a single argument, or any non-negative int32 without arguments.
+func placeholderRandomInt(args ...string) (string, error) {
+	if err := checkArgs(args, 0, 2); err != nil {
+		return "", err
+	}
+	if len(args) == 0 {
+		return strconv.Itoa(int(rand.Int32())), nil
+	}
+	bounds := make([]int, len(args))
+	for i, arg := range args {
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return "", err
+		}
+		bounds[i] = n
+	}
+	lo, hi := 0, bounds[0]
+	if len(bounds) > 1 {
+		lo, hi = bounds[0], bounds[1]
+	}
+	if hi <= lo {
+		return "", errutil.Explain(nil, "invalid range [%d,%d)", lo, hi)
+	}
+	return strconv.Itoa(lo + rand.IntN(hi-lo)), nil
+}
+
+// placeholderHostname returns the host name of the machine.
+func placeholderHostname(args ...string) (string, error) {
+	if err := checkArgs(args, 0, 0); err != nil {
+		return "", err
+	}
+	return os.Hostname()
+}
+
+// placeholderPID returns the process ID.
+func placeholderPID(args ...string) (string, error) {
+	if err := checkArgs(args, 0, 0); err != nil {
+		return "", err
+	}
+	return strconv.Itoa(os.Getpid()), nil
+}
+
+// placeholderFile returns the contents of a file, without trailing line
+// breaks, e.g. a secret mounted at /run/secrets.
+func placeholderFile(args ...string) (string, error) {
+	if err := checkArgs(args, 1, 1); err != nil {
+		return "", err
+	}
+	b, err := os.ReadFile(args[0])
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// placeholderBase64 decodes a base64 string.
+func placeholderBase64(args ...string) (string, error) {
+	if err := checkArgs(args, 1, 1); err != nil {
+		return "", err
+	}
+	b, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// placeholderUpper returns a string in upper case.
+func placeholderUpper(args ...string) (string, error) {
+	if err := checkArgs(args, 1, 1); err != nil {
+		return "", err
+	}
+	return strings.ToUpper(args[0]), nil
+}
+
+// placeholderLower returns a string in lower case.
+func placeholderLower(args ...string) (string, error) {
+	if err := checkArgs(args, 1, 1); err != nil {
+		return "", err
+	}
+	return strings.ToLower(args[0]), nil
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/stdlib/flatten"
+	"github.com/go-spring/stdlib/testing/assert"
+)
+
+func TestPlaceholderFunc(t *testing.T) {
+	conf.RegisterPlaceholderFunc("repeat", func(args ...string) (string, error) {
+		n, err := strconv.Atoi(args[1])
+		if err != nil {
+			return "", err
+		}
+		return strings.Repeat(args[0], n), nil
+	})
+
+	p := flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+		"app": map[string]any{
+			"name":  "Demo",
+			"token": "c2VjcmV0",
+		},
+		"hostname": "my-host",
+	}))
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv("PLACEHOLDER_TEST", "value")
+		s, err := conf.Resolve(p, "${env(PLACEHOLDER_TEST)}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("value")
+
+		s, err = conf.Resolve(p, "${env(PLACEHOLDER_UNSET, ${app.name})}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("Demo")
+
+		_, err = conf.Resolve(p, "${env(PLACEHOLDER_UNSET)}")
+		assert.Error(t, err).Matches(`placeholder "env\(PLACEHOLDER_UNSET\)" error: environment variable "PLACEHOLDER_UNSET" not set`)
+
+		s, err = conf.Resolve(p, "${env(PLACEHOLDER_UNSET):=fallback}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("fallback")
+	})
+
+	t.Run("random", func(t *testing.T) {
+		s, err := conf.Resolve(p, "${random.uuid}")
+		assert.That(t, err).Nil()
+		assert.String(t, s).Matches(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+		for range 100 {
+			s, err = conf.Resolve(p, "${random.int(1,5)}")
+			assert.That(t, err).Nil()
+			n, _ := strconv.Atoi(s)
+			assert.That(t, n >= 1 && n < 5).True()
+		}
+
+		_, err = conf.Resolve(p, "${random.int(5,1)}")
+		assert.Error(t, err).Matches(`invalid range \[5,1\)`)
+	})
+
+	t.Run("hostname and pid", func(t *testing.T) {
+		// a property of the same name wins over the bare form
+		s, err := conf.Resolve(p, "${hostname}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("my-host")
+
+		hostname, _ := os.Hostname()
+		s, err = conf.Resolve(p, "${hostname()}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal(hostname)
+
+		s, err = conf.Resolve(p, "app-${pid}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("app-" + strconv.Itoa(os.Getpid()))
+	})
+
+	t.Run("file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "password")
+		err := os.WriteFile(file, []byte("s3cret\n"), 0600)
+		assert.That(t, err).Nil()
+
+		s, err := conf.Resolve(p, "${file:"+file+"}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("s3cret")
+
+		_, err = conf.Resolve(p, "${file:/not/exist}")
+		assert.Error(t, err).Matches(`placeholder "file:/not/exist" error: .* no such file or directory`)
+
+		s, err = conf.Resolve(p, "${file:/not/exist:=dev}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("dev")
+	})
+
+	t.Run("string functions", func(t *testing.T) {
+		s, err := conf.Resolve(p, "${base64(${app.token})}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("secret")
+
+		s, err = conf.Resolve(p, "${upper(${app.name})}-${lower(${app.name})}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("DEMO-demo")
+
+		_, err = conf.Resolve(p, "${upper(a, b)}")
+		assert.Error(t, err).Matches(`placeholder "upper\(a, b\)" error: expects 1 arguments but got 2`)
+	})
+
+	t.Run("custom function", func(t *testing.T) {
+		s, err := conf.Resolve(p, "${repeat(ab, 3)}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("ababab")
+
+		assert.Panic(t, func() {
+			conf.RegisterPlaceholderFunc("repeat", nil)
+		}, "placeholder function repeat already exists")
+	})
+
+	t.Run("bind", func(t *testing.T) {
+		var v struct {
+			ID   string `value:"${random.uuid}"`
+			Port int    `value:"${random.int(8000,9000)}"`
+			Name string `value:"${app.name}"`
+		}
+		err := conf.Bind(p, &v)
+		assert.That(t, err).Nil()
+		assert.That(t, len(v.ID)).Equal(36)
+		assert.That(t, v.Port >= 8000 && v.Port < 9000).True()
+	})
+
+	t.Run("nested key isn't a function", func(t *testing.T) {
+		var v struct {
+			Server struct {
+				Hostname string `value:"${hostname}"`
+			} `value:"${server}"`
+		}
+		err := conf.Bind(p, &v)
+		assert.Error(t, err).Matches(`property "server.hostname" not exist`)
+	})
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-spring/stdlib/errutil"
+)
+
+// LoadConfigTree loads a directory tree in which every file holds the value
+// of one property, as Kubernetes mounts Secrets and ConfigMaps.
+//
+// The relative path of each file becomes a dotted key, and the trimmed file
+// content becomes the value. For example, given "configtree:/etc/secrets/":
+//
+//	/etc/secrets/db/password       -> db.password
+//	/etc/secrets/spring.app.name   -> spring.app.name
+//
+// Symbolic links are followed, and hidden entries such as the "..data"
+// directory that Kubernetes uses for atomic updates are skipped, so each
+// value is read once through its visible link. A directory reached again
+// through a link, e.g. a link to a parent directory, is read only once.
+//
+// If the directory does not exist and optional is true, it returns nil without error.
+func LoadConfigTree(optional bool, source string) (map[string]string, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && optional {
+			return nil, nil
+		}
+		return nil, errutil.Explain(err, "read config tree %s error", source)
+	}
+	if !info.IsDir() {
+		err = errutil.Explain(nil, "%s is not a directory", source)
+		return nil, errutil.Explain(err, "read config tree %s error", source)
+	}
+	ret := make(map[string]string)
+	if err = readConfigTree(source, "", ret, make(map[string]struct{})); err != nil {
+		return nil, errutil.Explain(err, "read config tree %s error", source)
+	}
+	return ret, nil
+}
+
+// readConfigTree reads all files under dir into ret, using prefix as the
+// key of dir itself. The real paths of the directories already read are
+// kept in visited, so that symbolic link cycles are skipped.
+func readConfigTree(dir string, prefix string, ret map[string]string, visited map[string]struct{}) error {
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	if _, ok := visited[realDir]; ok {
+		return nil
+	}
+	visited[realDir] = struct{}{}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		key := e.Name()
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		info, err := os.Stat(path) // follows symbolic links
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if err = readConfigTree(path, key, ret, visited); err != nil {
+				return err
+			}
+			continue
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		ret[key] = strings.TrimSpace(string(b))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-spring/stdlib/testing/assert"
+)
+
+func TestLoadConfigTree(t *testing.T) {
+
+	t.Run("not exist", func(t *testing.T) {
+		_, err := LoadConfigTree(false, "./nonexistent")
+		assert.Error(t, err).Matches("read config tree ./nonexistent error: .*no such file or directory")
+	})
+
+	t.Run("optional not exist", func(t *testing.T) {
+		m, err := Load("optional:configtree:./nonexistent")
+		assert.That(t, err).Nil()
+		assert.That(t, m).Nil()
+	})
+
+	t.Run("not a directory", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		err := os.WriteFile(file, []byte("a"), 0644)
+		assert.That(t, err).Nil()
+		_, err = LoadConfigTree(true, file)
+		assert.Error(t, err).Matches("is not a directory")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		dir := t.TempDir()
+
+		// mimic the layout of a kubernetes volume mount
+		data := filepath.Join(dir, "..2025_01_01")
+		assert.That(t, os.MkdirAll(filepath.Join(data, "db"), 0755)).Nil()
+		assert.That(t, os.WriteFile(filepath.Join(data, "db", "password"), []byte("secret\n"), 0644)).Nil()
+		assert.That(t, os.WriteFile(filepath.Join(data, "spring.app.name"), []byte(" demo "), 0644)).Nil()
+		assert.That(t, os.Symlink("..2025_01_01", filepath.Join(dir, "..data"))).Nil()
+		assert.That(t, os.Symlink("..data/db", filepath.Join(dir, "db"))).Nil()
+		assert.That(t, os.Symlink("..data/spring.app.name", filepath.Join(dir, "spring.app.name"))).Nil()
+
+		m, err := Load("configtree:" + dir)
+		assert.That(t, err).Nil()
+		assert.That(t, m).Equal(map[string]string{
+			"db.password":     "secret",
+			"spring.app.name": "demo",
+		})
+	})
+
+	t.Run("symlink cycle", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.That(t, os.MkdirAll(filepath.Join(dir, "a"), 0755)).Nil()
+		assert.That(t, os.WriteFile(filepath.Join(dir, "a", "b"), []byte("1"), 0644)).Nil()
+		assert.That(t, os.Symlink("..", filepath.Join(dir, "a", "link"))).Nil()
+
+		m, err := Load("configtree:" + dir)
+		assert.That(t, err).Nil()
+		assert.That(t, m).Equal(map[string]string{"a.b": "1"})
+	})
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-spring/stdlib/errutil"
+)
+
+// LoadEnv loads environment variables and returns them as properties.
+//
+// The source has the form <prefix>[?skip-unmatched=true], for example
+// "env:MYAPP_" or "env:MYAPP_?skip-unmatched=true". Variables starting
+// with the prefix are mapped to property keys:
+//   - The prefix is removed.
+//   - "__" is an escape for a literal underscore.
+//   - "_<n>_" and a trailing "_<n>" are list indices.
+//   - Remaining underscores '_' are replaced by dots '.'.
+//   - Keys are converted to lowercase.
+//
+// For example, MYAPP_SERVER_ADDRS_0_HOST becomes server.addrs[0].host and
+// MYAPP_READ__TIMEOUT becomes read_timeout. Variables that don't match the
+// prefix are kept under their original name unless skip-unmatched is set.
+// The optional flag is ignored, because the environment always exists.
+func LoadEnv(optional bool, source string) (map[string]string, error) {
+	prefix, skipUnmatched := source, false
+	if i := strings.IndexByte(source, '?'); i >= 0 {
+		q, err := url.ParseQuery(source[i+1:])
+		if err != nil {
+			return nil, errutil.Explain(err, "read env %s error", source)
+		}
+		if s := q.Get("skip-unmatched"); s != "" {
+			if skipUnmatched, err = strconv.ParseBool(s); err != nil {
+				return nil, errutil.Explain(err, "read env %s error", source)
+			}
+		}
+		prefix = source[:i]
+	}
+
+	ret := make(map[string]string)
+	for _, env := range os.Environ() {
+		k, v, _ := strings.Cut(env, "=")
+		if k == "" {
+			continue // Skip malformed env vars like "=::=:"
+		}
+		s, ok := strings.CutPrefix(k, prefix)
+		if !ok {
+			if !skipUnmatched {
+				ret[k] = v
+			}
+			continue
+		}
+		if s == "" {
+			continue
+		}
+		ret[envKey(s)] = v
+	}
+	return ret, nil
+}
+
+// envKey converts an environment variable name, without its prefix,
+// into a property key according to the rules of LoadEnv.
+func envKey(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] != '_' {
+			sb.WriteByte(s[i])
+			i++
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '_' {
+			sb.WriteByte('_')
+			i += 2
+			continue
+		}
+		j := i + 1
+		for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+			j++
+		}
+		if j > i+1 && sb.Len() > 0 && (j == len(s) || s[j] == '_') {
+			sb.WriteString("[" + s[i+1:j] + "]")
+			i = j
+			continue
+		}
+		sb.WriteByte('.')
+		i++
+	}
+	return strings.ToLower(sb.String())
+}
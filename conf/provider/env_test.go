@@ -0,0 +1,68 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/go-spring/stdlib/testing/assert"
+)
+
+func TestEnvKey(t *testing.T) {
+	for _, c := range []struct {
+		env string
+		key string
+	}{
+		{"DB_HOST", "db.host"},
+		{"READ__TIMEOUT", "read_timeout"},
+		{"SERVER_ADDRS_0_HOST", "server.addrs[0].host"},
+		{"SERVER_ADDRS_1", "server.addrs[1]"},
+		{"MATRIX_0_1", "matrix[0][1]"},
+		{"V2_NAME", "v2.name"},
+	} {
+		assert.That(t, envKey(c.env)).Equal(c.key)
+	}
+}
+
+func TestLoadEnv(t *testing.T) {
+
+	t.Run("invalid option", func(t *testing.T) {
+		_, err := LoadEnv(false, "MYAPP_?skip-unmatched=x")
+		assert.Error(t, err).Matches(`read env MYAPP_\?skip-unmatched=x error: .*invalid syntax`)
+	})
+
+	t.Run("keep unmatched", func(t *testing.T) {
+		t.Setenv("MYAPP_DB_HOST", "localhost")
+		t.Setenv("OTHER_VAR", "other")
+		m, err := LoadEnv(false, "MYAPP_")
+		assert.That(t, err).Nil()
+		assert.That(t, m["db.host"]).Equal("localhost")
+		assert.That(t, m["OTHER_VAR"]).Equal("other")
+	})
+
+	t.Run("skip unmatched", func(t *testing.T) {
+		t.Setenv("MYAPP_DB_HOST", "localhost")
+		t.Setenv("MYAPP_DB_ADDRS_0", "127.0.0.1")
+		t.Setenv("OTHER_VAR", "other")
+		m, err := Load("env:MYAPP_?skip-unmatched=true")
+		assert.That(t, err).Nil()
+		assert.That(t, m).Equal(map[string]string{
+			"db.host":     "localhost",
+			"db.addrs[0]": "127.0.0.1",
+		})
+	})
+}
@@ -0,0 +1,223 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-spring/spring-core/conf/reader"
+	"github.com/go-spring/stdlib/errutil"
+	"github.com/go-spring/stdlib/flatten"
+)
+
+// HTTPClient is the client used by the http and https providers.
+// It may be replaced in init functions, e.g. to configure TLS.
+var HTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// contentTypes maps the media types of configuration documents
+// to the file extensions of their readers.
+var contentTypes = map[string]string{
+	"application/json":       ".json",
+	"application/yaml":       ".yaml",
+	"application/x-yaml":     ".yaml",
+	"text/yaml":              ".yaml",
+	"text/x-yaml":            ".yaml",
+	"application/toml":       ".toml",
+	"text/x-java-properties": ".properties",
+}
+
+// httpCache remembers the last response of every url and set of request
+// headers, so that a reload can send If-None-Match and reuse the data when
+// the server answers 304. Sources that differ only in their headers, e.g.
+// their credentials, don't share an entry.
+var httpCache = struct {
+	sync.Mutex
+	m map[string]httpEntry
+}{m: make(map[string]httpEntry)}
+
+type httpEntry struct {
+	etag string
+	data map[string]string
+}
+
+// httpProvider returns a Provider that loads documents over the given scheme.
+func httpProvider(scheme string) Provider {
+	return func(optional bool, source string) (map[string]string, error) {
+		return LoadHTTP(optional, scheme+":"+source)
+	}
+}
+
+// LoadHTTP fetches a configuration document from an http or https url.
+//
+// The reader is chosen by the Content-Type of the response, then by the
+// extension of the url path, and otherwise the format is detected from the
+// content. Request headers are declared in the url fragment, which is never
+// sent to the server, for example:
+//
+//	https://config.example.com/app.yaml#header.Authorization=Bearer%20${config.token}
+//
+// Placeholders in imported sources are resolved against the loaded properties
+// and environment variables before the provider is called, so header values
+// should be read from property or environment variable keys rather than
+// written in the source. The fragment is left out of the name under which
+// the source is recorded and reported, see SourceName.
+//
+// A reload sends If-None-Match with the ETag of the previous response, and
+// the previous data is reused when the server answers 304 Not Modified.
+// If optional is true, a 404 response or an unreachable server is treated
+// as a missing source and returns nil without error.
+func LoadHTTP(optional bool, source string) (map[string]string, error) {
+	name := stripFragment(source)
+	u, err := url.Parse(source)
+	if err != nil {
+		return nil, errutil.Explain(nil, "read url %s error: invalid url", name)
+	}
+	header, err := parseHeaders(u.EscapedFragment())
+	if err != nil {
+		return nil, errutil.Explain(err, "read url %s error", name)
+	}
+	u.Fragment, u.RawFragment = "", ""
+	target := u.String()
+
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return nil, errutil.Explain(err, "read url %s error", target)
+	}
+	req.Header = header
+
+	key := cacheKey(target, header)
+	httpCache.Lock()
+	cached, hasCached := httpCache.m[key]
+	httpCache.Unlock()
+	if hasCached && cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		if optional {
+			return nil, nil
+		}
+		return nil, errutil.Explain(err, "read url %s error", target)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && hasCached:
+		return maps.Clone(cached.data), nil
+	case resp.StatusCode == http.StatusNotFound && optional:
+		return nil, nil
+	case resp.StatusCode != http.StatusOK:
+		err = errutil.Explain(nil, "unexpected status %s", resp.Status)
+		return nil, errutil.Explain(err, "read url %s error", target)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errutil.Explain(err, "read url %s error", target)
+	}
+
+	r := readerFor(resp.Header.Get("Content-Type"), u.Path)
+	m, err := r(b)
+	if err != nil {
+		return nil, errutil.Explain(err, "read url %s error", target)
+	}
+	data := flatten.Flatten(m)
+
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		httpCache.Lock()
+		httpCache.m[key] = httpEntry{etag: etag, data: maps.Clone(data)}
+		httpCache.Unlock()
+	}
+	return data, nil
+}
+
+// stripFragment removes the fragment of a url, which may hold secrets.
+func stripFragment(source string) string {
+	s, _, _ := strings.Cut(source, "#")
+	return s
+}
+
+// parseHeaders parses "header.<Name>=<value>" pairs from the escaped url
+// fragment. Names and values are unescaped once, and a "+" is kept as is,
+// so that tokens like base64 survive whether "+" is escaped or not.
+func parseHeaders(fragment string) (http.Header, error) {
+	header := make(http.Header)
+	if fragment == "" {
+		return header, nil
+	}
+	for _, pair := range strings.Split(fragment, "&") {
+		if pair == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(pair, "=")
+		k, err := url.PathUnescape(k)
+		if err != nil {
+			return nil, err
+		}
+		name, ok := strings.CutPrefix(k, "header.")
+		if !ok || name == "" {
+			return nil, errutil.Explain(nil, "unknown parameter %s", k)
+		}
+		if v, err = url.PathUnescape(v); err != nil {
+			return nil, errutil.Explain(err, "invalid value of header %s", name)
+		}
+		header.Add(name, v)
+	}
+	return header, nil
+}
+
+// cacheKey returns the key of a response in httpCache: the url and a hash
+// of the canonicalized request headers, which keeps credentials out of it.
+func cacheKey(target string, header http.Header) string {
+	if len(header) == 0 {
+		return target
+	}
+	h := sha256.New()
+	for _, name := range slices.Sorted(maps.Keys(header)) {
+		for _, v := range header[name] {
+			_, _ = fmt.Fprintf(h, "%s:%s\n", name, v)
+		}
+	}
+	return target + "#" + hex.EncodeToString(h.Sum(nil))
+}
+
+// readerFor picks the Reader for a response by its content type,
+// then by the extension of the url path, and falls back to detection.
+func readerFor(contentType string, urlPath string) reader.Reader {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		if r, ok := reader.Lookup(contentTypes[mediaType]); ok {
+			return r
+		}
+	}
+	if r, ok := reader.Lookup(path.Ext(urlPath)); ok {
+		return r
+	}
+	return reader.Detect
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-spring/stdlib/testing/assert"
+)
+
+func TestLoadHTTP(t *testing.T) {
+
+	var requests []*http.Request
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		switch r.URL.Path {
+		case "/app.yaml":
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			_, _ = w.Write([]byte("server:\n  port: 8080"))
+		case "/config":
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			_, _ = w.Write([]byte(`{"server": {"port": 9090}}`))
+		case "/secret":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte("a=b"))
+		case "/echo":
+			_, _ = w.Write([]byte("auth=" + r.Header.Get("Authorization")))
+		case "/private":
+			if r.Header.Get("If-None-Match") == `"p"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"p"`)
+			_, _ = w.Write([]byte("auth=" + r.Header.Get("Authorization")))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer svr.Close()
+
+	t.Run("extension", func(t *testing.T) {
+		requests = nil
+		m, err := Load(svr.URL + "/app.yaml")
+		assert.That(t, err).Nil()
+		assert.That(t, m).Equal(map[string]string{"server.port": "8080"})
+
+		// a reload sends If-None-Match and reuses the cached data
+		m, err = Load(svr.URL + "/app.yaml")
+		assert.That(t, err).Nil()
+		assert.That(t, m).Equal(map[string]string{"server.port": "8080"})
+		assert.That(t, len(requests)).Equal(2)
+		assert.That(t, requests[1].Header.Get("If-None-Match")).Equal(`"v1"`)
+
+		// the cached data can't be changed through the returned map
+		m["server.port"] = "1"
+		m, err = Load(svr.URL + "/app.yaml")
+		assert.That(t, err).Nil()
+		assert.That(t, m).Equal(map[string]string{"server.port": "8080"})
+	})
+
+	t.Run("content type", func(t *testing.T) {
+		m, err := Load(svr.URL + "/config")
+		assert.That(t, err).Nil()
+		assert.That(t, m).Equal(map[string]string{"server.port": "9090"})
+	})
+
+	t.Run("headers", func(t *testing.T) {
+		_, err := Load(svr.URL + "/secret")
+		assert.Error(t, err).Matches("unexpected status 401 Unauthorized")
+
+		m, err := Load(svr.URL + "/secret#header.Authorization=Bearer%20token")
+		assert.That(t, err).Nil()
+		assert.That(t, m).Equal(map[string]string{"a": "b"})
+	})
+
+	t.Run("header value escapes", func(t *testing.T) {
+		m, err := Load(svr.URL + "/echo#header.Authorization=Basic%20a+b%2Bc%25")
+		assert.That(t, err).Nil()
+		assert.That(t, m).Equal(map[string]string{"auth": "Basic a+b+c%"})
+
+		_, err = Load(svr.URL + "/echo#header.Authorization=a%zz")
+		assert.Error(t, err).Matches("read url .*/echo error: invalid url")
+	})
+
+	t.Run("cache per headers", func(t *testing.T) {
+		m, err := Load(svr.URL + "/private#header.Authorization=a")
+		assert.That(t, err).Nil()
+		assert.That(t, m).Equal(map[string]string{"auth": "a"})
+
+		m, err = Load(svr.URL + "/private#header.Authorization=b")
+		assert.That(t, err).Nil()
+		assert.That(t, m).Equal(map[string]string{"auth": "b"})
+
+		m, err = Load(svr.URL + "/private#header.Authorization=a")
+		assert.That(t, err).Nil()
+		assert.That(t, m).Equal(map[string]string{"auth": "a"})
+	})
+
+	t.Run("unknown fragment parameter", func(t *testing.T) {
+		_, err := Load(svr.URL + "/secret#token=x")
+		assert.Error(t, err).Matches("unknown parameter token")
+		assert.That(t, strings.Contains(err.Error(), "token=x")).False()
+	})
+
+	t.Run("source name", func(t *testing.T) {
+		name := SourceName("optional:" + svr.URL + "/secret#header.Authorization=Bearer%20token")
+		assert.That(t, name).Equal("optional:" + svr.URL + "/secret")
+		assert.That(t, SourceName("file:./conf/a#b.yaml")).Equal("file:./conf/a#b.yaml")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := Load(svr.URL + "/none.yaml")
+		assert.Error(t, err).Matches("unexpected status 404 Not Found")
+
+		m, err := Load("optional:" + svr.URL + "/none.yaml")
+		assert.That(t, err).Nil()
+		assert.That(t, m).Nil()
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		m, err := Load("optional:http://127.0.0.1:0/app.yaml")
+		assert.That(t, err).Nil()
+		assert.That(t, m).Nil()
+	})
+}
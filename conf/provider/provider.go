@@ -17,6 +17,8 @@
 package provider
 
 import (
+	"errors"
+	"net/url"
 	"os"
 	"strings"
 
@@ -29,6 +31,10 @@ var providers = map[string]Provider{}
 
 func init() {
 	Register("file", LoadFile)
+	Register("env", LoadEnv)
+	Register("configtree", LoadConfigTree)
+	Register("http", httpProvider("http"))
+	Register("https", httpProvider("https"))
 }
 
 // Provider defines a function type that provides configuration data from a specific source.
@@ -61,35 +67,23 @@ func Register(name string, p Provider) {
 //	<path> (defaults to file provider)
 //
 // Examples:
-//   - "file:./config.yaml"                    // file provider, required
-//   - "optional:file:./config.yaml"           // file provider, optional
-//   - "./config.yaml"                         // shorthand for file:./config.yaml
-//   - "etcd:localhost:2379/config"            // custom provider
-//   - "optional:etcd:localhost:2379/config"   // custom provider, optional
+//   - "file:./config.yaml"                          // file provider, required
+//   - "optional:file:./config.yaml"                 // file provider, optional
+//   - "./config.yaml"                               // shorthand for file:./config.yaml
+//   - "file:/etc/config/application"                // format detected from content
+//   - "file:/etc/config/application?format=yaml"    // format given explicitly
+//   - "env:MYAPP_?skip-unmatched=true"              // environment variables with prefix MYAPP_
+//   - "optional:configtree:/etc/secrets/"           // one file per key, optional
+//   - "https://config.example.com/app.yaml"        // remote document over http(s)
+//   - "etcd:localhost:2379/config"                  // custom provider
+//   - "optional:etcd:localhost:2379/config"         // custom provider, optional
 //
 // When optional is true and the source does not exist, Load returns (nil, nil).
 func Load(source string) (map[string]string, error) {
 	// For example, a spring.config.import value of optional:file:./myconfig.properties
 	// allows your application to start, even if the myconfig.properties file is missing.
 
-	var (
-		provider string
-		optional bool
-	)
-
-	// Parse the source string in format [optional:]<provider>:<path> or just <path>
-	if ss := strings.SplitN(source, ":", 3); len(ss) == 3 {
-		optional = ss[0] == "optional"
-		provider = ss[1]
-		source = ss[2]
-	} else if len(ss) == 2 {
-		provider = ss[0]
-		source = ss[1]
-	} else if len(ss) == 1 {
-		provider = "file"
-		source = ss[0]
-	}
-
+	provider, optional, source := parseSource(source)
 	p, ok := providers[provider]
 	if !ok {
 		err := errutil.Explain(nil, "unsupported provider type %s", provider)
@@ -98,15 +92,56 @@ func Load(source string) (map[string]string, error) {
 	return p(optional, source)
 }
 
+// SourceName returns the name under which a source is recorded, watched
+// and reported. The url fragment of http and https sources, which may
+// declare request headers with credentials, is left out of the name.
+func SourceName(source string) string {
+	provider, _, _ := parseSource(source)
+	if provider == "http" || provider == "https" {
+		return stripFragment(source)
+	}
+	return source
+}
+
+// parseSource parses a source string in format [optional:]<provider>:<path>
+// or just <path>, the latter defaulting to the file provider.
+func parseSource(source string) (provider string, optional bool, path string) {
+	if s, ok := strings.CutPrefix(source, "optional:"); ok {
+		optional = true
+		source = s
+	}
+	if p, s, ok := strings.Cut(source, ":"); ok {
+		return p, optional, s
+	}
+	return "file", optional, source
+}
+
 // LoadFile loads a configuration file and returns its content as a flattened map[string]string.
+// The file format is taken from a trailing "?format=<ext>" parameter if present,
+// otherwise from the file extension, otherwise it is detected from the content.
 // If the file does not exist and optional is true, it returns nil without error.
 func LoadFile(optional bool, source string) (map[string]string, error) {
-	m, err := reader.ReadFile(source)
+	file, format := splitFormat(source)
+	m, err := reader.ReadFileAs(file, format)
 	if err != nil {
-		if os.IsNotExist(err) && optional {
+		if errors.Is(err, os.ErrNotExist) && optional {
 			return nil, nil
 		}
-		return nil, errutil.Explain(err, "read file %s error", source)
+		return nil, errutil.Explain(err, "read file %s error", file)
 	}
 	return flatten.Flatten(m), nil
 }
+
+// splitFormat splits a "?format=<ext>" parameter off the end of the source.
+// Sources without such a parameter are returned unchanged.
+func splitFormat(source string) (path, format string) {
+	i := strings.LastIndexByte(source, '?')
+	if i < 0 {
+		return source, ""
+	}
+	q, err := url.ParseQuery(source[i+1:])
+	if err != nil || !q.Has("format") {
+		return source, ""
+	}
+	return source[:i], q.Get("format")
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+var watchers = map[string]Watcher{}
+
+func init() {
+	RegisterWatcher("file", WatchFile)
+}
+
+// Watcher is the optional companion of a Provider that reports changes of
+// a configuration source. It starts watching in the background and calls
+// onChange every time the source changes, until ctx is done. onChange may
+// be called from another goroutine.
+type Watcher func(ctx context.Context, optional bool, source string, onChange func()) error
+
+// RegisterWatcher registers a Watcher for a specific configuration source type.
+// Must be called in init functions only.
+func RegisterWatcher(name string, w Watcher) {
+	if name == "" {
+		panic("watcher name cannot be empty")
+	}
+	if _, ok := watchers[name]; ok {
+		panic("watcher " + name + " already exists")
+	}
+	watchers[name] = w
+}
+
+// Watch starts watching a configuration source in the same format as Load.
+// It returns false if the provider of the source has no Watcher.
+func Watch(ctx context.Context, source string, onChange func()) (bool, error) {
+	provider, optional, source := parseSource(source)
+	w, ok := watchers[provider]
+	if !ok {
+		return false, nil
+	}
+	if err := w(ctx, optional, source, onChange); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// WatchInterval is the polling interval of WatchFile.
+var WatchInterval = time.Second
+
+// fileState is the part of a file's metadata used to detect changes.
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+// statFile returns the current state of a file.
+func statFile(file string) fileState {
+	info, err := os.Stat(file)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{
+		exists:  true,
+		size:    info.Size(),
+		modTime: info.ModTime(),
+	}
+}
+
+// WatchFile watches a configuration file by polling its size and
+// modification time every WatchInterval. Creating or removing the
+// file is reported as a change as well.
+func WatchFile(ctx context.Context, optional bool, source string, onChange func()) error {
+	file, _ := splitFormat(source)
+	last := statFile(file)
+	go func() {
+		ticker := time.NewTicker(WatchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if curr := statFile(file); curr != last {
+					last = curr
+					onChange()
+				}
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-spring/stdlib/testing/assert"
+)
+
+func TestWatch(t *testing.T) {
+
+	t.Run("no watcher", func(t *testing.T) {
+		ok, err := Watch(t.Context(), "env:MYAPP_", func() {})
+		assert.That(t, err).Nil()
+		assert.That(t, ok).False()
+	})
+
+	t.Run("file", func(t *testing.T) {
+		interval := WatchInterval
+		WatchInterval = 10 * time.Millisecond
+		t.Cleanup(func() { WatchInterval = interval })
+
+		file := filepath.Join(t.TempDir(), "app.properties")
+		changed := make(chan struct{}, 10)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		ok, err := Watch(ctx, "optional:file:"+file, func() { changed <- struct{}{} })
+		assert.That(t, err).Nil()
+		assert.That(t, ok).True()
+
+		// the file is created
+		err = os.WriteFile(file, []byte("a=1"), 0644)
+		assert.That(t, err).Nil()
+		select {
+		case <-changed:
+		case <-time.After(time.Second):
+			t.Fatal("change not reported")
+		}
+
+		// the file is modified
+		err = os.WriteFile(file, []byte("a=12"), 0644)
+		assert.That(t, err).Nil()
+		select {
+		case <-changed:
+		case <-time.After(time.Second):
+			t.Fatal("change not reported")
+		}
+	})
+}
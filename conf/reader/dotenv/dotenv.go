@@ -0,0 +1,269 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dotenv
+
+import (
+	"os"
+	"strings"
+
+	"github.com/go-spring/stdlib/errutil"
+)
+
+// EnvPrefix is the prefix stripped from variable names, in line with
+// the way Go-Spring maps "GS_" environment variables to properties.
+const EnvPrefix = "GS_"
+
+// Read parses []byte in the dotenv format into map.
+//
+// Supported syntax:
+//
+//	# comment
+//	KEY=value                 # inline comment
+//	export KEY=value
+//	KEY='literal ${NOT_EXPANDED}'
+//	KEY="line1\nline2 ${OTHER}"
+//	KEY=${OTHER:-fallback}
+//
+// Variable names are converted to property keys the same way as
+// environment variables: the "GS_" prefix is removed, underscores
+// are replaced by dots, and the result is lowercased.
+//
+// References of the form $VAR, ${VAR} and ${VAR:-default} are expanded
+// in unquoted and double-quoted values. Variables defined earlier in
+// the file take precedence over the process environment. Placeholders
+// that are not variable names, such as ${spring.app.name}, are kept
+// as-is so that they can be resolved later as property references.
+func Read(b []byte) (map[string]any, error) {
+	vars := make(map[string]string)
+	ret := make(map[string]any)
+
+	s := strings.ReplaceAll(string(b), "\r\n", "\n")
+	for line := 1; s != ""; line++ {
+		var str string
+		if i := strings.IndexByte(s, '\n'); i >= 0 {
+			str, s = s[:i], s[i+1:]
+		} else {
+			str, s = s, ""
+		}
+
+		str = strings.TrimSpace(str)
+		if str == "" || str[0] == '#' {
+			continue
+		}
+		if after, ok := strings.CutPrefix(str, "export "); ok {
+			str = strings.TrimSpace(after)
+		}
+
+		k, v, ok := strings.Cut(str, "=")
+		if !ok {
+			return nil, errutil.Explain(nil, "dotenv: Line %d: missing '=' in %q", line, str)
+		}
+		if k = strings.TrimSpace(k); k == "" {
+			return nil, errutil.Explain(nil, "dotenv: Line %d: empty key", line)
+		}
+		if strings.ContainsAny(k, " \t") {
+			return nil, errutil.Explain(nil, "dotenv: Line %d: invalid key %q", line, k)
+		}
+
+		v = strings.TrimLeft(v, " \t")
+		var (
+			val string
+			n   int
+			err error
+		)
+		switch {
+		case strings.HasPrefix(v, "'"):
+			val, n, err = readSingleQuoted(v, s)
+		case strings.HasPrefix(v, `"`):
+			val, n, err = readDoubleQuoted(v, s, vars)
+		default:
+			val = expand(trimComment(v), vars, false)
+		}
+		if err != nil {
+			return nil, errutil.Explain(err, "dotenv: Line %d", line)
+		}
+
+		// quoted values may span multiple lines
+		for range n {
+			_, s, _ = strings.Cut(s, "\n")
+			line++
+		}
+
+		vars[k] = val
+		ret[propKey(k)] = val
+	}
+	return ret, nil
+}
+
+// propKey converts a variable name into a property key.
+func propKey(k string) string {
+	k = strings.TrimPrefix(k, EnvPrefix)
+	k = strings.ReplaceAll(k, "_", ".")
+	return strings.ToLower(k)
+}
+
+// trimComment removes an inline comment from an unquoted value.
+// A '#' only starts a comment when it is preceded by whitespace.
+func trimComment(v string) string {
+	for i := 0; i < len(v); i++ {
+		if v[i] == '#' && (i == 0 || v[i-1] == ' ' || v[i-1] == '\t') {
+			v = v[:i]
+			break
+		}
+	}
+	return strings.TrimSpace(v)
+}
+
+// readSingleQuoted reads a single-quoted value, which is taken literally.
+// It returns the value and the number of extra lines consumed from rest.
+func readSingleQuoted(v string, rest string) (string, int, error) {
+	body, n, ok := findClosingQuote(v[1:], rest, '\'')
+	if !ok {
+		return "", 0, errutil.Explain(nil, "unterminated single-quoted value")
+	}
+	return body, n, nil
+}
+
+// readDoubleQuoted reads a double-quoted value, processing escape
+// sequences and expanding variable references.
+// It returns the value and the number of extra lines consumed from rest.
+func readDoubleQuoted(v string, rest string, vars map[string]string) (string, int, error) {
+	body, n, ok := findClosingQuote(v[1:], rest, '"')
+	if !ok {
+		return "", 0, errutil.Explain(nil, "unterminated double-quoted value")
+	}
+	return expand(body, vars, true), n, nil
+}
+
+// findClosingQuote searches for the closing quote in s, continuing into
+// the following lines if necessary. Backslash escapes are honoured for
+// double quotes only. It returns the quoted content and the number of
+// extra lines read from rest.
+func findClosingQuote(s string, rest string, quote byte) (string, int, bool) {
+	var sb strings.Builder
+	for n := 0; ; n++ {
+		for i := 0; i < len(s); i++ {
+			if quote == '"' && s[i] == '\\' && i+1 < len(s) {
+				sb.WriteByte(s[i])
+				sb.WriteByte(s[i+1])
+				i++
+				continue
+			}
+			if s[i] == quote {
+				return sb.String(), n, true
+			}
+			sb.WriteByte(s[i])
+		}
+		if rest == "" {
+			return "", 0, false
+		}
+		sb.WriteByte('\n')
+		var ok bool
+		s, rest, ok = strings.Cut(rest, "\n")
+		if !ok {
+			rest = ""
+		}
+	}
+}
+
+// expand replaces $VAR, ${VAR} and ${VAR:-default} references in s.
+// When escapes is true, backslash escape sequences (\n, \t, \", \$ ...)
+// are processed as well, so that an escaped dollar stays literal.
+func expand(s string, vars map[string]string, escapes bool) string {
+	if !strings.ContainsAny(s, `$\`) {
+		return s
+	}
+	lookup := func(name string) (string, bool) {
+		if v, ok := vars[name]; ok {
+			return v, true
+		}
+		return os.LookupEnv(name)
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if escapes && c == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(s[i])
+			}
+			continue
+		}
+		if c != '$' || i+1 >= len(s) {
+			sb.WriteByte(c)
+			continue
+		}
+		if s[i+1] == '{' {
+			end := strings.IndexByte(s[i+2:], '}')
+			if end < 0 {
+				sb.WriteString(s[i:])
+				break
+			}
+			name, def, hasDef := strings.Cut(s[i+2:i+2+end], ":-")
+			if !isName(name) {
+				// not a variable reference, e.g. a ${key} property placeholder
+				sb.WriteString(s[i : i+3+end])
+				i += end + 2
+				continue
+			}
+			if v, ok := lookup(name); ok && (v != "" || !hasDef) {
+				sb.WriteString(v)
+			} else if hasDef {
+				sb.WriteString(expand(def, vars, false))
+			}
+			i += end + 2
+			continue
+		}
+		j := i + 1
+		for j < len(s) && isNameChar(s[j]) {
+			j++
+		}
+		if j == i+1 {
+			sb.WriteByte(c)
+			continue
+		}
+		v, _ := lookup(s[i+1 : j])
+		sb.WriteString(v)
+		i = j - 1
+	}
+	return sb.String()
+}
+
+// isName reports whether s is a valid variable name.
+func isName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isNameChar(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNameChar reports whether c can appear in an unbraced variable name.
+func isNameChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dotenv
+
+import (
+	"testing"
+
+	"github.com/go-spring/stdlib/testing/assert"
+)
+
+func TestRead(t *testing.T) {
+
+	t.Run("missing equal sign", func(t *testing.T) {
+		_, err := Read([]byte("A=1\nB"))
+		assert.Error(t, err).Matches(`dotenv: Line 2: missing '=' in "B"`)
+	})
+
+	t.Run("empty key", func(t *testing.T) {
+		_, err := Read([]byte("=1"))
+		assert.Error(t, err).Matches(`dotenv: Line 1: empty key`)
+	})
+
+	t.Run("unterminated quote", func(t *testing.T) {
+		_, err := Read([]byte(`A="abc`))
+		assert.Error(t, err).Matches(`dotenv: Line 1: unterminated double-quoted value`)
+	})
+
+	t.Run("basic type", func(t *testing.T) {
+		r, err := Read([]byte(`
+			# comment line
+			EMPTY=
+			BOOL=false
+			INT=3
+			STRING=hello # inline comment
+			HASH=a#b
+			export EXPORTED=yes
+			GS_SPRING_APP_NAME=demo
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"empty":           "",
+			"bool":            "false",
+			"int":             "3",
+			"string":          "hello",
+			"hash":            "a#b",
+			"exported":        "yes",
+			"spring.app.name": "demo",
+		})
+	})
+
+	t.Run("quoted values", func(t *testing.T) {
+		r, err := Read([]byte(`
+			SINGLE='a # ${HOST} \n'
+			DOUBLE="a # \"b\"\tc"
+			MULTI="line1
+line2"
+			AFTER=ok
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"single": `a # ${HOST} \n`,
+			"double": "a # \"b\"\tc",
+			"multi":  "line1\nline2",
+			"after":  "ok",
+		})
+	})
+
+	t.Run("interpolation", func(t *testing.T) {
+		t.Setenv("DOTENV_TEST_PORT", "3306")
+		r, err := Read([]byte(`
+			DB_HOST=localhost
+			DB_URL=mysql://${DB_HOST}:$DOTENV_TEST_PORT/db
+			DB_USER=${DB_USERNAME:-root}
+			DB_PASS="\${literal}"
+			DB_NAME=${spring.app.name}
+			DB_OPTS=${UNDEFINED_VAR}
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"db.host": "localhost",
+			"db.url":  "mysql://localhost:3306/db",
+			"db.user": "root",
+			"db.pass": "${literal}",
+			"db.name": "${spring.app.name}",
+			"db.opts": "",
+		})
+	})
+}
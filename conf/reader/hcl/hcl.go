@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hcl
+
+import (
+	"fmt"
+
+	"github.com/go-spring/stdlib/errutil"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+)
+
+// Read parses []byte in the hcl format into map.
+//
+// Labeled blocks become nested maps keyed by their labels, and objects
+// assigned with "=" become maps, so that
+//
+//	db = { host = "localhost" }
+//	server "a" { port = 8080 }
+//	server "b" { port = 9090 }
+//
+// is read as db.host, server.a.port and server.b.port. Unlabeled blocks
+// are repeatable and always become a list, whether the block appears once
+// or more, so "rule { name = "r1" }" is read as rule[0].name. Lists of
+// objects, e.g. "rules = [{ a = 1 }, { b = 2 }]", are kept as lists.
+func Read(b []byte) (map[string]any, error) {
+	f, err := hcl.ParseBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := f.Node.(*ast.ObjectList)
+	if !ok {
+		return nil, errutil.Explain(nil, "hcl: root should be an object")
+	}
+	return readObject(list)
+}
+
+// readObject reads the items of an object or of the document root.
+func readObject(list *ast.ObjectList) (map[string]any, error) {
+	ret := make(map[string]any)
+	for _, item := range list.Items {
+		v, err := readValue(item.Val)
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]string, len(item.Keys))
+		for i, k := range item.Keys {
+			keys[i] = fmt.Sprint(k.Token.Value())
+		}
+		name := keys[0]
+		_, isObject := item.Val.(*ast.ObjectType)
+		switch {
+		case len(keys) > 1: // labeled block
+			m := ret
+			for i, k := range keys[:len(keys)-1] {
+				sub, ok := m[k].(map[string]any)
+				if !ok {
+					if _, exists := m[k]; exists {
+						return nil, errutil.Explain(nil, "hcl: Line %d: key %q conflicts with block %q", item.Pos().Line, k, keys[:i+1])
+					}
+					sub = make(map[string]any)
+					m[k] = sub
+				}
+				m = sub
+			}
+			label := keys[len(keys)-1]
+			if _, exists := m[label]; exists {
+				return nil, errutil.Explain(nil, "hcl: Line %d: block %q is duplicated", item.Pos().Line, keys)
+			}
+			m[label] = v
+		case isObject && !item.Assign.IsValid(): // unlabeled block
+			arr, ok := ret[name].([]any)
+			if _, exists := ret[name]; exists && !ok {
+				return nil, errutil.Explain(nil, "hcl: Line %d: block %q conflicts with key", item.Pos().Line, name)
+			}
+			ret[name] = append(arr, v)
+		default:
+			if _, exists := ret[name]; exists {
+				return nil, errutil.Explain(nil, "hcl: Line %d: key %q is duplicated", item.Pos().Line, name)
+			}
+			ret[name] = v
+		}
+	}
+	return ret, nil
+}
+
+// readValue reads an object, a list or a literal value.
+func readValue(n ast.Node) (any, error) {
+	switch x := n.(type) {
+	case *ast.ObjectType:
+		return readObject(x.List)
+	case *ast.ListType:
+		ret := make([]any, len(x.List))
+		for i, e := range x.List {
+			v, err := readValue(e)
+			if err != nil {
+				return nil, err
+			}
+			ret[i] = v
+		}
+		return ret, nil
+	default:
+		var v any
+		if err := hcl.DecodeObject(&v, n); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hcl
+
+import (
+	"testing"
+
+	"github.com/go-spring/stdlib/testing/assert"
+)
+
+func TestRead(t *testing.T) {
+
+	t.Run("invalid hcl format", func(t *testing.T) {
+		_, err := Read([]byte(`a = {`))
+		assert.Error(t, err).Matches("object expected closing RBRACE")
+	})
+
+	t.Run("empty document", func(t *testing.T) {
+		r, err := Read([]byte(``))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{})
+	})
+
+	t.Run("basic type", func(t *testing.T) {
+		r, err := Read([]byte(`
+			# comment
+			empty  = ""
+			bool   = false
+			int    = 3
+			float  = 3.5
+			string = "hello" // inline comment
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"empty":  "",
+			"bool":   false,
+			"int":    3,
+			"float":  3.5,
+			"string": "hello",
+		})
+	})
+
+	t.Run("list", func(t *testing.T) {
+		r, err := Read([]byte(`
+			tags  = ["go", "spring"]
+			ports = [8080, 9090]
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"tags":  []any{"go", "spring"},
+			"ports": []any{8080, 9090},
+		})
+	})
+
+	t.Run("blocks", func(t *testing.T) {
+		r, err := Read([]byte(`
+			db = {
+				host = "localhost"
+				port = 3306
+			}
+			service "a" {
+				port = 8080
+			}
+			service "b" {
+				port = 9090
+			}
+			rule {
+				name = "r1"
+			}
+			rule {
+				name = "r2"
+			}
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"db": map[string]any{
+				"host": "localhost",
+				"port": 3306,
+			},
+			"service": map[string]any{
+				"a": map[string]any{"port": 8080},
+				"b": map[string]any{"port": 9090},
+			},
+			"rule": []any{
+				map[string]any{"name": "r1"},
+				map[string]any{"name": "r2"},
+			},
+		})
+	})
+
+	t.Run("one unlabeled block", func(t *testing.T) {
+		r, err := Read([]byte(`
+			rule {
+				a = 1
+			}
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"rule": []any{
+				map[string]any{"a": 1},
+			},
+		})
+	})
+
+	t.Run("two unlabeled blocks", func(t *testing.T) {
+		r, err := Read([]byte(`
+			rule {
+				a = 1
+			}
+			rule {
+				b = 2
+			}
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"rule": []any{
+				map[string]any{"a": 1},
+				map[string]any{"b": 2},
+			},
+		})
+	})
+
+	t.Run("list of objects", func(t *testing.T) {
+		r, err := Read([]byte(`
+			rules = [{ a = 1 }, { b = 2 }]
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"rules": []any{
+				map[string]any{"a": 1},
+				map[string]any{"b": 2},
+			},
+		})
+	})
+
+	t.Run("duplicate labeled block", func(t *testing.T) {
+		_, err := Read([]byte(`
+			service "a" {
+				port = 8080
+			}
+			service "a" {
+				port = 9090
+			}
+		`))
+		assert.Error(t, err).Matches(`hcl: Line 5: block \["service" "a"\] is duplicated`)
+	})
+
+	t.Run("duplicate key", func(t *testing.T) {
+		_, err := Read([]byte(`
+			port = 1
+			port = 2
+		`))
+		assert.Error(t, err).Matches(`hcl: Line 3: key "port" is duplicated`)
+	})
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ini
+
+import (
+	"strings"
+
+	"github.com/go-spring/stdlib/errutil"
+)
+
+// Read parses []byte in the ini format into map.
+//
+// Supported syntax:
+//
+//	; comment
+//	# comment
+//	global = value
+//	[server]
+//	port = 8080          ; inline comment
+//	host: "localhost"
+//	[remote "origin"]    -> remote.origin
+//	[db.primary]         -> db.primary
+//
+// Keys defined before any section header are placed at the top level,
+// and keys inside a section are prefixed by the section name. A key that
+// appears more than once in the same section becomes a list, e.g.
+//
+//	[server]
+//	addr = a
+//	addr = b              -> server.addr[0]=a, server.addr[1]=b
+//
+// Sections with the same name are merged.
+func Read(b []byte) (map[string]any, error) {
+	ret := make(map[string]any)
+	section := ret
+
+	s := strings.ReplaceAll(string(b), "\r\n", "\n")
+	for i, str := range strings.Split(s, "\n") {
+		line := i + 1
+
+		str = strings.TrimSpace(str)
+		if str == "" || str[0] == ';' || str[0] == '#' {
+			continue
+		}
+
+		if str[0] == '[' {
+			name, err := parseSection(str)
+			if err != nil {
+				return nil, errutil.Explain(err, "ini: Line %d", line)
+			}
+			m, ok := ret[name].(map[string]any)
+			if !ok {
+				if _, exists := ret[name]; exists {
+					return nil, errutil.Explain(nil, "ini: Line %d: section %q conflicts with key", line, name)
+				}
+				m = make(map[string]any)
+				ret[name] = m
+			}
+			section = m
+			continue
+		}
+
+		k, v, ok := cutKeyValue(str)
+		if !ok {
+			return nil, errutil.Explain(nil, "ini: Line %d: missing '=' in %q", line, str)
+		}
+		if k = strings.TrimSpace(k); k == "" {
+			return nil, errutil.Explain(nil, "ini: Line %d: empty key", line)
+		}
+
+		val, err := parseValue(strings.TrimSpace(v))
+		if err != nil {
+			return nil, errutil.Explain(err, "ini: Line %d", line)
+		}
+
+		switch old := section[k].(type) {
+		case nil:
+			section[k] = val
+		case string:
+			section[k] = []any{old, val}
+		case []any:
+			section[k] = append(old, val)
+		default:
+			return nil, errutil.Explain(nil, "ini: Line %d: key %q conflicts with section", line, k)
+		}
+	}
+	return ret, nil
+}
+
+// parseSection parses a section header such as [name] or [name "sub"].
+func parseSection(str string) (string, error) {
+	if !strings.HasSuffix(str, "]") {
+		return "", errutil.Explain(nil, "unterminated section header %q", str)
+	}
+	name := strings.TrimSpace(str[1 : len(str)-1])
+	if i := strings.IndexByte(name, '"'); i > 0 {
+		sub := strings.TrimSpace(name[i:])
+		if len(sub) < 2 || sub[len(sub)-1] != '"' {
+			return "", errutil.Explain(nil, "invalid section header %q", str)
+		}
+		name = strings.TrimSpace(name[:i]) + "." + sub[1:len(sub)-1]
+	}
+	if name == "" {
+		return "", errutil.Explain(nil, "empty section name")
+	}
+	return name, nil
+}
+
+// cutKeyValue splits a line at the first '=' or ':' separator.
+func cutKeyValue(str string) (k, v string, ok bool) {
+	i := strings.IndexAny(str, "=:")
+	if i < 0 {
+		return "", "", false
+	}
+	return str[:i], str[i+1:], true
+}
+
+// parseValue unquotes a quoted value or strips an inline comment
+// from an unquoted one. Inline comments start with " ;" or " #".
+func parseValue(v string) (string, error) {
+	if v == "" {
+		return "", nil
+	}
+	if q := v[0]; q == '"' || q == '\'' {
+		j := strings.IndexByte(v[1:], q)
+		if j < 0 {
+			return "", errutil.Explain(nil, "unterminated quoted value %s", v)
+		}
+		return v[1 : j+1], nil
+	}
+	for i := 1; i < len(v); i++ {
+		if (v[i] == ';' || v[i] == '#') && (v[i-1] == ' ' || v[i-1] == '\t') {
+			return strings.TrimSpace(v[:i]), nil
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ini
+
+import (
+	"testing"
+
+	"github.com/go-spring/stdlib/testing/assert"
+)
+
+func TestRead(t *testing.T) {
+
+	t.Run("missing equal sign", func(t *testing.T) {
+		_, err := Read([]byte("[a]\nb"))
+		assert.Error(t, err).Matches(`ini: Line 2: missing '=' in "b"`)
+	})
+
+	t.Run("unterminated section", func(t *testing.T) {
+		_, err := Read([]byte("[a"))
+		assert.Error(t, err).Matches(`ini: Line 1: unterminated section header`)
+	})
+
+	t.Run("unterminated quote", func(t *testing.T) {
+		_, err := Read([]byte(`a = "b`))
+		assert.Error(t, err).Matches(`ini: Line 1: unterminated quoted value`)
+	})
+
+	t.Run("section conflicts with key", func(t *testing.T) {
+		_, err := Read([]byte("a = 1\n[a]"))
+		assert.Error(t, err).Matches(`ini: Line 2: section "a" conflicts with key`)
+	})
+
+	t.Run("basic type", func(t *testing.T) {
+		r, err := Read([]byte(`
+			; comment
+			# comment
+			empty =
+			bool = false
+			int = 3
+			string = hello ; inline comment
+			quoted = "a ; b"
+			colon: value
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"empty":  "",
+			"bool":   "false",
+			"int":    "3",
+			"string": "hello",
+			"quoted": "a ; b",
+			"colon":  "value",
+		})
+	})
+
+	t.Run("sections", func(t *testing.T) {
+		r, err := Read([]byte(`
+			name = demo
+			[server]
+			host = localhost
+			[remote "origin"]
+			url = git@example.com
+			[server]
+			port = 8080
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"name": "demo",
+			"server": map[string]any{
+				"host": "localhost",
+				"port": "8080",
+			},
+			"remote.origin": map[string]any{
+				"url": "git@example.com",
+			},
+		})
+	})
+
+	t.Run("repeated keys", func(t *testing.T) {
+		r, err := Read([]byte(`
+			[server]
+			addr = a
+			addr = b
+			addr = c
+		`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"server": map[string]any{
+				"addr": []any{"a", "b", "c"},
+			},
+		})
+	})
+}
@@ -17,9 +17,14 @@
 package reader
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/go-spring/spring-core/conf/reader/dotenv"
+	"github.com/go-spring/spring-core/conf/reader/hcl"
+	"github.com/go-spring/spring-core/conf/reader/ini"
 	"github.com/go-spring/spring-core/conf/reader/json"
 	"github.com/go-spring/spring-core/conf/reader/prop"
 	"github.com/go-spring/spring-core/conf/reader/toml"
@@ -34,6 +39,9 @@ func init() {
 	Register(prop.Read, ".properties")
 	Register(yaml.Read, ".yaml", ".yml")
 	Register(toml.Read, ".toml", ".tml")
+	Register(dotenv.Read, ".env")
+	Register(hcl.Read, ".hcl")
+	Register(ini.Read, ".ini", ".cfg")
 }
 
 // Reader parses raw bytes into a nested map[string]any.
@@ -53,19 +61,73 @@ func Register(r Reader, ext ...string) {
 	}
 }
 
+// Lookup returns the Reader registered for a format. The format may be
+// given either as a file extension (".yaml") or as a bare name ("yaml").
+func Lookup(format string) (Reader, bool) {
+	if format == "" {
+		return nil, false
+	}
+	if !strings.HasPrefix(format, ".") {
+		format = "." + format
+	}
+	r, ok := readers[format]
+	return r, ok
+}
+
+// Detect sniffs the content and parses it with the first built-in reader
+// that accepts it. JSON objects and "---" prefixed YAML documents are
+// recognised by their first token; other content is tried as TOML, then
+// YAML, then properties.
+func Detect(b []byte) (map[string]any, error) {
+	var candidates []Reader
+	s := bytes.TrimSpace(b)
+	switch {
+	case bytes.HasPrefix(s, []byte("{")):
+		candidates = []Reader{json.Read, yaml.Read}
+	case bytes.HasPrefix(s, []byte("---")):
+		candidates = []Reader{yaml.Read}
+	default:
+		candidates = []Reader{toml.Read, yaml.Read, prop.Read}
+	}
+	var firstErr error
+	for _, r := range candidates {
+		m, err := r(b)
+		if err == nil {
+			return m, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, errutil.Explain(firstErr, "unable to detect file format")
+}
+
 // ReadFile reads a file and parses its content based on file extension.
-// Returns an error if the file cannot be read or the file type is unsupported.
+// If the extension is missing or not registered, the format is detected
+// from the content, see Detect.
 func ReadFile(file string) (map[string]any, error) {
-	ext := filepath.Ext(file)
-	r, ok := readers[ext]
-	if !ok {
-		err := errutil.Explain(nil, "unsupported file type %s", ext)
+	return ReadFileAs(file, "")
+}
+
+// ReadFileAs reads a file and parses its content in the given format,
+// ignoring the file extension. An empty format behaves like ReadFile.
+// Returns an error if the file cannot be read or the format is unsupported.
+func ReadFileAs(file string, format string) (map[string]any, error) {
+	r, ok := Lookup(format)
+	if !ok && format != "" {
+		err := errutil.Explain(nil, "unsupported file type %s", format)
 		return nil, errutil.Explain(err, "read file %s error", file)
 	}
+	if !ok {
+		r, ok = Lookup(filepath.Ext(file))
+	}
 	b, err := os.ReadFile(file)
 	if err != nil {
 		return nil, errutil.Explain(err, "read file %s error", file)
 	}
+	if !ok {
+		r = Detect
+	}
 	m, err := r(b)
 	if err != nil {
 		return nil, errutil.Explain(err, "read file %s error", file)
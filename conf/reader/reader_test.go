@@ -0,0 +1,67 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reader
+
+import (
+	"testing"
+
+	"github.com/go-spring/stdlib/testing/assert"
+)
+
+func TestDetect(t *testing.T) {
+
+	t.Run("json", func(t *testing.T) {
+		r, err := Detect([]byte(`{"a": {"b": 1}}`))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"a": map[string]any{"b": float64(1)},
+		})
+	})
+
+	t.Run("yaml document", func(t *testing.T) {
+		r, err := Detect([]byte("---\na: 1"))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{"a": 1})
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		r, err := Detect([]byte("a:\n  b: c"))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"a": map[any]any{"b": "c"},
+		})
+	})
+
+	t.Run("toml", func(t *testing.T) {
+		r, err := Detect([]byte("[a]\nb = \"c\""))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"a": map[string]any{"b": "c"},
+		})
+	})
+
+	t.Run("properties", func(t *testing.T) {
+		r, err := Detect([]byte("a.b=c\nd=e"))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{"a.b": "c", "d": "e"})
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := Detect([]byte(`{"a": `))
+		assert.Error(t, err).Matches("unable to detect file format: unexpected end of JSON input")
+	})
+}
@@ -17,14 +17,105 @@
 package yaml
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-spring/stdlib/flatten"
 	"gopkg.in/yaml.v2"
 )
 
+// ProfileKey is the key that gates a document on the active profiles.
+// Its value is a profile name, a comma-separated list, or a list.
+const ProfileKey = "spring.config.activate.on-profile"
+
 // Read parses []byte in the yaml format into map.
+//
+// A file may hold several "---" separated documents. Documents are merged
+// in order, so later documents override earlier ones. Documents gated by
+// ProfileKey are skipped here; use ReadDocuments and Profiles to apply
+// them for the active profiles.
 func Read(b []byte) (map[string]any, error) {
-	ret := make(map[string]any)
-	if err := yaml.Unmarshal(b, &ret); err != nil {
+	docs, err := ReadDocuments(b)
+	if err != nil {
 		return nil, err
 	}
+	ret := make(map[string]any)
+	for _, doc := range docs {
+		if Profiles(doc) != nil {
+			continue
+		}
+		mergeMap(ret, doc)
+	}
 	return ret, nil
 }
+
+// ReadDocuments parses all "---" separated documents in order.
+// Empty documents are omitted.
+func ReadDocuments(b []byte) ([]map[string]any, error) {
+	var docs []map[string]any
+	d := yaml.NewDecoder(bytes.NewReader(b))
+	for {
+		var m map[string]any
+		if err := d.Decode(&m); err != nil {
+			if errors.Is(err, io.EOF) {
+				return docs, nil
+			}
+			return nil, err
+		}
+		if m != nil {
+			docs = append(docs, m)
+		}
+	}
+}
+
+// Profiles returns the profiles that a document is gated on,
+// or nil if the document does not declare ProfileKey.
+func Profiles(doc map[string]any) []string {
+	var profiles []string
+	for k, v := range flatten.Flatten(doc) {
+		s, ok := strings.CutPrefix(k, ProfileKey)
+		if !ok || (s != "" && s[0] != '[') {
+			continue
+		}
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				profiles = append(profiles, p)
+			}
+		}
+	}
+	return profiles
+}
+
+// mergeMap merges src into dst. Nested maps are merged recursively,
+// any other value in src replaces the one in dst.
+func mergeMap(dst map[string]any, src map[string]any) {
+	for k, v := range src {
+		if m, ok := toStringMap(v); ok {
+			if old, ok := toStringMap(dst[k]); ok {
+				mergeMap(old, m)
+				dst[k] = old
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+// toStringMap converts a yaml map into a map[string]any.
+func toStringMap(v any) (map[string]any, bool) {
+	switch m := v.(type) {
+	case map[string]any:
+		return m, true
+	case map[any]any:
+		ret := make(map[string]any, len(m))
+		for k, v := range m {
+			ret[fmt.Sprint(k)] = v
+		}
+		return ret, true
+	default:
+		return nil, false
+	}
+}
@@ -160,4 +160,62 @@ func TestRead(t *testing.T) {
 			"map":   map[any]any{},
 		})
 	})
+
+	t.Run("multiple documents", func(t *testing.T) {
+		str := `
+server:
+	host: localhost
+	port: 8080
+list: [1, 2, 3]
+---
+server:
+	port: 9090
+list: [4]
+---
+spring.config.activate.on-profile: dev
+server:
+	port: 7070
+		`
+		str = strings.ReplaceAll(str, "\t", "  ")
+		r, err := Read([]byte(str))
+		assert.That(t, err).Nil()
+		assert.That(t, r).Equal(map[string]any{
+			"server": map[string]any{
+				"host": "localhost",
+				"port": 9090,
+			},
+			"list": []any{4},
+		})
+	})
+}
+
+func TestReadDocuments(t *testing.T) {
+
+	t.Run("invalid document", func(t *testing.T) {
+		_, err := ReadDocuments([]byte("a: 1\n---\n{"))
+		assert.Error(t, err).Matches("did not find expected node content")
+	})
+
+	t.Run("profiles", func(t *testing.T) {
+		str := `
+a: 1
+---
+---
+spring:
+	config:
+		activate:
+			on-profile: dev, test
+a: 2
+---
+spring.config.activate.on-profile: [prod]
+a: 3
+		`
+		str = strings.ReplaceAll(str, "\t", "  ")
+		docs, err := ReadDocuments([]byte(str))
+		assert.That(t, err).Nil()
+		assert.That(t, len(docs)).Equal(3)
+		assert.That(t, Profiles(docs[0])).Nil()
+		assert.That(t, Profiles(docs[1])).Equal([]string{"dev", "test"})
+		assert.That(t, Profiles(docs[2])).Equal([]string{"prod"})
+	})
 }
@@ -0,0 +1,278 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/go-spring/stdlib/errutil"
+	"github.com/go-spring/stdlib/flatten"
+)
+
+// Relaxed binding lets a key written in one style find a property written
+// in another. Each segment of a key is split into words at '-', '_' and
+// camel-case boundaries, so the following keys are equivalent:
+//
+//	spring.http.server.read-timeout    (kebab-case, canonical)
+//	spring.http.server.readTimeout     (camelCase)
+//	spring.http.server.read_timeout    (snake_case)
+//	SPRING_HTTP_SERVER_READ_TIMEOUT    (environment variable)
+//
+// The dotted spellings must agree; if two of them hold different values
+// binding fails. The environment form, and the dotted form that GS_
+// variables are mapped to (spring.http.server.read.timeout), are only
+// looked up in environment layers, where they override the dotted
+// spellings. The environment form is only used for keys of several
+// segments, so that keys like "home" or "path" don't pick up unrelated
+// variables.
+//
+// The spellings are looked up layer by layer, see LayeredStorage, so that
+// any spelling of a higher layer, e.g. the command line, wins over all the
+// spellings of the lower layers, e.g. the environment and files. A storage
+// that isn't layered is a single environment layer.
+
+// Layer is a layer of a LayeredStorage.
+type Layer struct {
+	flatten.Storage
+	Env bool // whether the layer holds environment variables
+}
+
+// LayeredStorage is a storage made of layers, e.g. the command line, the
+// environment and files, whose lookups relaxed binding walks in order.
+type LayeredStorage interface {
+	flatten.Storage
+	Layers() []Layer // from higher to lower precedence
+}
+
+// storageLayers returns the layers of a storage.
+func storageLayers(p flatten.Storage) []Layer {
+	if l, ok := p.(LayeredStorage); ok {
+		return l.Layers()
+	}
+	return []Layer{{Storage: p, Env: true}}
+}
+
+// keyWords splits a key segment into lower-case words,
+// e.g. "readTimeout", "read-timeout" and "READ_TIMEOUT" all
+// give ["read", "timeout"].
+func keyWords(s string) []string {
+	var (
+		ret  []string
+		word []rune
+	)
+	flush := func() {
+		if len(word) > 0 {
+			ret = append(ret, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	rs := []rune(s)
+	for i, r := range rs {
+		if r == '-' || r == '_' {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			prev := rs[i-1]
+			nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return ret
+}
+
+// keySegment is a segment of a key with its trailing indices, e.g.
+// "servers[0]" has name "servers" and indices ["0"].
+type keySegment struct {
+	name    string
+	indices []string
+}
+
+// splitKey splits a key into segments.
+func splitKey(key string) []keySegment {
+	var ret []keySegment
+	for _, s := range strings.Split(key, ".") {
+		seg := keySegment{name: s}
+		if i := strings.IndexByte(s, '['); i >= 0 {
+			seg.name = s[:i]
+			for _, idx := range strings.Split(s[i+1:], "[") {
+				seg.indices = append(seg.indices, strings.TrimSuffix(idx, "]"))
+			}
+		}
+		ret = append(ret, seg)
+	}
+	return ret
+}
+
+// joinKey joins segments into a key, formatting each
+// segment name from its words with fn.
+func joinKey(segments []keySegment, fn func(words []string) string) string {
+	var sb strings.Builder
+	for i, seg := range segments {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		if words := keyWords(seg.name); len(words) > 0 {
+			sb.WriteString(fn(words))
+		} else {
+			sb.WriteString(seg.name)
+		}
+		for _, idx := range seg.indices {
+			sb.WriteString("[" + idx + "]")
+		}
+	}
+	return sb.String()
+}
+
+// relaxedKeys returns the spellings of a key that relaxed binding looks up.
+// The dotted spellings start with the key itself; the env spellings are the
+// environment variable form and the form of mapped GS_ variables.
+func relaxedKeys(key string) (dotted []string, env []string) {
+	dotted = []string{key}
+	if key == "" {
+		return dotted, nil
+	}
+	segments := splitKey(key)
+	add := func(arr []string, s string) []string {
+		if slices.Contains(dotted, s) || slices.Contains(arr, s) {
+			return arr
+		}
+		return append(arr, s)
+	}
+	dotted = add(dotted, joinKey(segments, func(w []string) string {
+		return strings.Join(w, "-")
+	}))
+	dotted = add(dotted, joinKey(segments, func(w []string) string {
+		return strings.Join(w, "_")
+	}))
+	dotted = add(dotted, joinKey(segments, func(w []string) string {
+		for i := 1; i < len(w); i++ {
+			w[i] = strings.ToUpper(w[i][:1]) + w[i][1:]
+		}
+		return strings.Join(w, "")
+	}))
+	if len(segments) > 1 {
+		var parts []string
+		for _, seg := range segments {
+			parts = append(parts, keyWords(seg.name)...)
+			parts = append(parts, seg.indices...)
+		}
+		env = add(env, strings.ToUpper(strings.Join(parts, "_")))
+	}
+	env = add(env, joinKey(segments, func(w []string) string {
+		return strings.Join(w, ".")
+	}))
+	return dotted, env
+}
+
+// lookupValue looks up the value of a key using relaxed binding.
+// It returns the key under which the value was found.
+func lookupValue(p flatten.Storage, key string) (string, string, bool, error) {
+	dotted, env := relaxedKeys(key)
+	for _, layer := range storageLayers(p) {
+		if layer.Env {
+			k, v, ok, err := lookupSpellings(layer, env)
+			if err != nil || ok {
+				return k, v, ok, err
+			}
+		}
+		k, v, ok, err := lookupSpellings(layer, dotted)
+		if err != nil || ok {
+			return k, v, ok, err
+		}
+	}
+	return "", "", false, nil
+}
+
+// lookupSpellings looks up the spellings of a key in a layer.
+// It fails if two of them hold different values.
+func lookupSpellings(p flatten.Storage, keys []string) (string, string, bool, error) {
+	var (
+		foundKey string
+		foundVal string
+		found    bool
+	)
+	for _, k := range keys {
+		v, ok := p.Value(k)
+		if !ok {
+			continue
+		}
+		if !found {
+			foundKey, foundVal, found = k, v, true
+			continue
+		}
+		if v != foundVal {
+			return "", "", false, errutil.Explain(nil,
+				"property %q conflicts with %q: %q != %q", foundKey, k, foundVal, v)
+		}
+	}
+	return foundKey, foundVal, found, nil
+}
+
+// lookupExists reports whether a key exists, as a simple value
+// or as a map or slice node, using relaxed binding.
+func lookupExists(p flatten.Storage, key string) bool {
+	dotted, env := relaxedKeys(key)
+	for _, layer := range storageLayers(p) {
+		keys := dotted
+		if layer.Env {
+			keys = append(env, dotted...)
+		}
+		for _, k := range keys {
+			if layer.Exists(k) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookupMapKeys collects the child keys of a map node using relaxed
+// binding. It returns the key under which the children were found.
+func lookupMapKeys(p flatten.Storage, key string, result map[string]struct{}) string {
+	dotted, _ := relaxedKeys(key)
+	for _, k := range dotted {
+		if p.MapKeys(k, result) {
+			return k
+		}
+	}
+	return key
+}
+
+// lookupSliceEntries collects the entries of a slice node using relaxed
+// binding. The entries are keyed by the requested key, whichever
+// spelling they were found under.
+func lookupSliceEntries(p flatten.Storage, key string, result map[string]string) bool {
+	dotted, _ := relaxedKeys(key)
+	for _, k := range dotted {
+		m := make(map[string]string)
+		if !p.SliceEntries(k, m) {
+			continue
+		}
+		for s, v := range m {
+			result[key+strings.TrimPrefix(s, k)] = v
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-spring/stdlib/errutil"
+	"github.com/go-spring/stdlib/flatten"
+)
+
+// Unmarshaler is implemented by types that bind themselves from the
+// subtree of properties under their key. Keys of the storage passed to
+// UnmarshalProperties are relative to that key, e.g. "host" rather than
+// "db.host", and the empty key refers to the value of the key itself.
+type Unmarshaler interface {
+	UnmarshalProperties(p flatten.Storage) error
+}
+
+var (
+	unmarshalerType     = reflect.TypeFor[Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// implementsUnmarshaler reports whether t, or a pointer to t,
+// implements Unmarshaler, encoding.TextUnmarshaler or json.Unmarshaler.
+func implementsUnmarshaler(t reflect.Type) bool {
+	if t.Kind() != reflect.Pointer {
+		t = reflect.PointerTo(t)
+	}
+	return t.Implements(unmarshalerType) ||
+		t.Implements(textUnmarshalerType) ||
+		t.Implements(jsonUnmarshalerType)
+}
+
+// usesUnmarshaler reports whether t is bound through the unmarshaler it
+// implements. The encoding.TextUnmarshaler and json.Unmarshaler of struct,
+// map, slice and array types are only used for scalar values, that is if
+// the key holds a simple value, or is absent and may have a default value,
+// so that a config struct is still bound field by field from its subtree.
+func usesUnmarshaler(p flatten.Storage, t reflect.Type, param BindParam) bool {
+	if !implementsUnmarshaler(t) {
+		return false
+	}
+	if reflect.PointerTo(t).Implements(unmarshalerType) || t.Implements(unmarshalerType) {
+		return true
+	}
+	et := t
+	if et.Kind() == reflect.Pointer {
+		et = et.Elem()
+	}
+	switch et.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+	default:
+		return true
+	}
+	if _, _, ok, err := lookupValue(p, param.Key); ok || err != nil {
+		return true
+	}
+	return !lookupExists(p, param.Key)
+}
+
+// bindUnmarshaler binds v through the Unmarshaler, encoding.TextUnmarshaler
+// or json.Unmarshaler it implements, in that order of preference. It returns
+// false if v implements none of them, or doesn't use them for the property,
+// see usesUnmarshaler. A nil pointer target is allocated.
+func bindUnmarshaler(p flatten.Storage, v reflect.Value, t reflect.Type, param BindParam) (bool, error) {
+	if !usesUnmarshaler(p, t, param) {
+		return false, nil
+	}
+
+	var ptr reflect.Value
+	switch {
+	case t.Kind() == reflect.Pointer:
+		ptr = reflect.New(t.Elem())
+	case v.CanAddr():
+		ptr = v.Addr()
+	default:
+		return false, nil
+	}
+
+	if u, ok := ptr.Interface().(Unmarshaler); ok {
+		if err := u.UnmarshalProperties(newSubStorage(p, param.Key)); err != nil {
+			err = errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+			return true, collectError(param, t, "", err)
+		}
+		if t.Kind() == reflect.Pointer {
+			v.Set(ptr)
+		}
+		return true, nil
+	}
+
+	val, err := resolve(p, param, nil)
+	if err != nil {
+		err = errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		return true, collectError(param, t, "", err)
+	}
+	if val = strings.TrimSpace(val); val == "" {
+		return true, nil
+	}
+
+	if u, ok := ptr.Interface().(encoding.TextUnmarshaler); ok {
+		err = u.UnmarshalText([]byte(val))
+	} else {
+		// scalar values are usually unquoted, e.g. "red" rather than "\"red\"".
+		b := []byte(val)
+		if !json.Valid(b) {
+			b = []byte(strconv.Quote(val))
+		}
+		err = ptr.Interface().(json.Unmarshaler).UnmarshalJSON(b)
+	}
+	if err != nil {
+		err = errutil.Explain(err, "bind path=%s type=%s error", param.Path, v.Type().String())
+		return true, collectError(param, t, val, err)
+	}
+	if t.Kind() == reflect.Pointer {
+		v.Set(ptr)
+	}
+	return true, nil
+}
+
+// subStorage is a view of the subtree of a Storage under a key.
+type subStorage struct {
+	p      flatten.Storage
+	prefix string
+}
+
+// newSubStorage returns a view of the subtree of p under key.
+func newSubStorage(p flatten.Storage, key string) flatten.Storage {
+	if key == "" {
+		return p
+	}
+	return &subStorage{p: p, prefix: key}
+}
+
+// fullKey returns the key in the underlying storage.
+func (s *subStorage) fullKey(key string) string {
+	switch {
+	case key == "":
+		return s.prefix
+	case key[0] == '[':
+		return s.prefix + key
+	default:
+		return s.prefix + "." + key
+	}
+}
+
+// Exists checks whether the key exists in the subtree.
+func (s *subStorage) Exists(key string) bool {
+	return s.p.Exists(s.fullKey(key))
+}
+
+// Value returns the value of the key in the subtree.
+func (s *subStorage) Value(key string) (string, bool) {
+	return s.p.Value(s.fullKey(key))
+}
+
+// MapKeys collects the child keys of a map node in the subtree.
+func (s *subStorage) MapKeys(key string, result map[string]struct{}) bool {
+	return s.p.MapKeys(s.fullKey(key), result)
+}
+
+// SliceEntries collects the entries of a slice node in the subtree,
+// keyed relative to the subtree.
+func (s *subStorage) SliceEntries(key string, result map[string]string) bool {
+	m := make(map[string]string)
+	if !s.p.SliceEntries(s.fullKey(key), m) {
+		return false
+	}
+	for k, v := range m {
+		k = strings.TrimPrefix(k, s.prefix)
+		result[strings.TrimPrefix(k, ".")] = v
+	}
+	return true
+}
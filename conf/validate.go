@@ -0,0 +1,480 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-spring/stdlib/errutil"
+)
+
+// Declarative validation uses the `validate` tag, a comma-separated list of
+// rules, e.g.:
+//
+//	type PoolConfig struct {
+//	    Name     string `value:"${name}" validate:"required,pattern=^[a-z]+$"`
+//	    MinConns int    `value:"${min-conns:=1}" validate:"min=1"`
+//	    MaxConns int    `value:"${max-conns:=10}" validate:"gtefield=MinConns,max=100"`
+//	}
+//
+// A comma inside a rule argument is written as "\,". The format rules
+// (pattern, email, hostport, url) accept an empty string, so they can be
+// combined with required or left out for optional values.
+
+// validateRule checks a value against the argument of a rule.
+// It returns an error if the argument is invalid for the value.
+type validateRule func(v reflect.Value, arg string) (bool, error)
+
+var validateRules = map[string]validateRule{
+	"required": ruleRequired,
+	"min":      ruleMin,
+	"max":      ruleMax,
+	"len":      ruleLen,
+	"pattern":  rulePattern,
+	"oneof":    ruleOneOf,
+	"email":    ruleEmail,
+	"hostport": ruleHostPort,
+	"url":      ruleURL,
+}
+
+// crossFieldRules compare a field with the sibling field named by the
+// rule argument; the function receives the result of the comparison.
+var crossFieldRules = map[string]func(c int) bool{
+	"eqfield":  func(c int) bool { return c == 0 },
+	"nefield":  func(c int) bool { return c != 0 },
+	"gtfield":  func(c int) bool { return c > 0 },
+	"gtefield": func(c int) bool { return c >= 0 },
+	"ltfield":  func(c int) bool { return c < 0 },
+	"ltefield": func(c int) bool { return c <= 0 },
+}
+
+// rule is a parsed validation rule, e.g. "min=1".
+type rule struct {
+	name string
+	arg  string
+}
+
+// String returns the rule as written in the tag.
+func (r rule) String() string {
+	if r.arg == "" {
+		return r.name
+	}
+	return r.name + "=" + r.arg
+}
+
+// parseRules parses a `validate` tag into rules.
+func parseRules(tag string) []rule {
+	var (
+		ret []rule
+		sb  strings.Builder
+	)
+	add := func() {
+		s := strings.TrimSpace(sb.String())
+		sb.Reset()
+		if s == "" {
+			return
+		}
+		name, arg, _ := strings.Cut(s, "=")
+		ret = append(ret, rule{name: strings.TrimSpace(name), arg: arg})
+	}
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			sb.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			add()
+		default:
+			sb.WriteByte(tag[i])
+		}
+	}
+	add()
+	return ret
+}
+
+// hasRule reports whether the `validate` tag contains the named rule.
+func hasRule(tag reflect.StructTag, name string) bool {
+	return slices.ContainsFunc(parseRules(tag.Get("validate")), func(r rule) bool {
+		return r.name == name
+	})
+}
+
+// validateValue runs the rules of the `validate` tag and the `expr` tag
+// that only depend on the value itself. Rules referencing sibling fields
+// are run by ValidateCrossFields once the whole struct is bound.
+func validateValue(v reflect.Value, tag reflect.StructTag) error {
+	for _, r := range parseRules(tag.Get("validate")) {
+		if _, ok := crossFieldRules[r.name]; ok {
+			continue
+		}
+		fn, ok := validateRules[r.name]
+		if !ok {
+			return errutil.Explain(nil, "unknown validate rule %q", r.name)
+		}
+		ok, err := fn(v, r.arg)
+		if err != nil {
+			return errutil.Explain(err, "validate rule %q error", r.String())
+		}
+		if !ok {
+			return errutil.Explain(nil, "validate failed on %q for value %v", r.String(), v.Interface())
+		}
+	}
+	if s, ok := tag.Lookup("expr"); ok && len(s) > 0 && len(exprNames(s)) == 0 {
+		return validateField(s, v.Interface(), nil)
+	}
+	return nil
+}
+
+// ValidateCrossFields runs the rules of the `value` fields of the struct v
+// that reference sibling fields: the eqfield, nefield, gtfield, gtefield,
+// ltfield and ltefield rules, and `expr` tags using sibling names, e.g.
+//
+//	MaxConns int `value:"${max-conns}" expr:"$ >= MinConns"`
+//
+// It is called once all fields of the struct are bound. Nil pointer fields
+// are skipped by the comparison rules.
+func ValidateCrossFields(v reflect.Value, param BindParam) error {
+	t := v.Type()
+	for i := range t.NumField() {
+		ft := t.Field(i)
+		tag, ok := ft.Tag.Lookup("value")
+		if !ok || !ft.IsExported() {
+			continue
+		}
+
+		var rules []rule
+		for _, r := range parseRules(ft.Tag.Get("validate")) {
+			if _, ok = crossFieldRules[r.name]; ok {
+				rules = append(rules, r)
+			}
+		}
+		var names []string
+		exprTag := ft.Tag.Get("expr")
+		if exprTag != "" {
+			if names = exprNames(exprTag); len(names) == 0 {
+				exprTag = ""
+			}
+		}
+		if len(rules) == 0 && exprTag == "" {
+			continue
+		}
+
+		subParam := BindParam{
+			Key:    param.Key,
+			Path:   param.Path + "." + ft.Name,
+			Errors: param.Errors,
+		}
+		_ = subParam.BindTag(tag, ft.Tag) // reported when bound
+
+		fv := derefValue(v.Field(i))
+		err := validateCrossRules(v, fv, rules)
+		if err == nil && exprTag != "" {
+			var siblings map[string]any
+			if siblings, err = fieldValues(v, exprTag, names); err == nil {
+				var cur any
+				if fv.IsValid() {
+					cur = fv.Interface()
+				}
+				err = validateField(exprTag, cur, siblings)
+			}
+		}
+		if err != nil {
+			var val string
+			if fv.IsValid() {
+				val = fmt.Sprint(fv.Interface())
+			}
+			err = errutil.Explain(err, "validate path=%s type=%s error", subParam.Path, ft.Type.String())
+			if err = collectError(subParam, ft.Type, val, err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// siblingField returns the exported field of the struct type t by name.
+func siblingField(t reflect.Type, name string) (reflect.StructField, error) {
+	sf, ok := t.FieldByName(name)
+	if !ok {
+		return sf, errutil.Explain(nil, "field %q not found", name)
+	}
+	if !sf.IsExported() {
+		return sf, errutil.Explain(nil, "field %q is unexported", name)
+	}
+	return sf, nil
+}
+
+// validateCrossRules runs the comparison rules of the field fv.
+func validateCrossRules(v reflect.Value, fv reflect.Value, rules []rule) error {
+	for _, r := range rules {
+		sf, err := siblingField(v.Type(), r.arg)
+		if err != nil {
+			return errutil.Explain(err, "validate rule %q error", r.String())
+		}
+		sv, err := v.FieldByIndexErr(sf.Index)
+		if err != nil {
+			continue // nil embedded pointer
+		}
+		sv = derefValue(sv)
+		if !fv.IsValid() || !sv.IsValid() {
+			continue
+		}
+		c, err := compareValues(fv, sv)
+		if err != nil {
+			return errutil.Explain(err, "validate rule %q error", r.String())
+		}
+		if !crossFieldRules[r.name](c) {
+			return errutil.Explain(nil, "validate failed on %q for value %v and %v", r.String(), fv.Interface(), sv.Interface())
+		}
+	}
+	return nil
+}
+
+// fieldValues returns the sibling fields referenced by the validation
+// expression tag by name, with pointers dereferenced. Names that are neither
+// validation functions nor exported fields of the struct v are reported.
+func fieldValues(v reflect.Value, tag string, names []string) (map[string]any, error) {
+	ret := make(map[string]any)
+	for _, name := range names {
+		sf, ok := v.Type().FieldByName(name)
+		if !ok {
+			return nil, errutil.Explain(nil, "validate expr %q error: unknown name %q", tag, name)
+		}
+		if !sf.IsExported() {
+			return nil, errutil.Explain(nil, "validate expr %q error: field %q is unexported", tag, name)
+		}
+		ret[name] = nil
+		if fv, err := v.FieldByIndexErr(sf.Index); err == nil {
+			if fv = derefValue(fv); fv.IsValid() {
+				ret[name] = fv.Interface()
+			}
+		}
+	}
+	return ret, nil
+}
+
+// derefValue follows pointers, returning an invalid value for nil.
+func derefValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// compareValues compares two numbers, strings or times.
+func compareValues(a, b reflect.Value) (int, error) {
+	if x, ok := numberOf(a); ok {
+		if y, ok := numberOf(b); ok {
+			switch {
+			case x < y:
+				return -1, nil
+			case x > y:
+				return 1, nil
+			default:
+				return 0, nil
+			}
+		}
+	}
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return strings.Compare(a.String(), b.String()), nil
+	}
+	if x, ok := a.Interface().(time.Time); ok {
+		if y, ok := b.Interface().(time.Time); ok {
+			return x.Compare(y), nil
+		}
+	}
+	return 0, errutil.Explain(nil, "can't compare %s with %s", a.Type(), b.Type())
+}
+
+// numberOf returns the value of a numeric kind as float64.
+func numberOf(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// lengthOf returns the length of a string (in runes) or a collection.
+func lengthOf(v reflect.Value) (int, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(v.String()), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), true
+	default:
+		return 0, false
+	}
+}
+
+// ruleBound returns the measure of a value and the bound of a min or max
+// rule: the length for strings and collections, otherwise the number.
+// The bound of a type with a converter is written in its format, e.g.
+// "1s" for time.Duration or "1MB" for ByteSize.
+func ruleBound(v reflect.Value, arg string) (float64, float64, error) {
+	if n, ok := lengthOf(v); ok {
+		bound, err := strconv.Atoi(arg)
+		if err != nil {
+			return 0, 0, err
+		}
+		return float64(n), float64(bound), nil
+	}
+	x, ok := numberOf(v)
+	if !ok {
+		return 0, 0, errutil.Explain(nil, "type %s isn't supported", v.Type())
+	}
+	if fn := converters[v.Type()]; fn != nil {
+		out := reflect.ValueOf(fn).Call([]reflect.Value{reflect.ValueOf(arg)})
+		if !out[1].IsNil() {
+			return 0, 0, out[1].Interface().(error)
+		}
+		y, _ := numberOf(out[0])
+		return x, y, nil
+	}
+	y, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return x, y, nil
+}
+
+// ruleRequired checks that the value isn't the zero value.
+func ruleRequired(v reflect.Value, _ string) (bool, error) {
+	if n, ok := lengthOf(v); ok {
+		return n > 0, nil
+	}
+	return !v.IsZero(), nil
+}
+
+// ruleMin checks the minimum length or value.
+func ruleMin(v reflect.Value, arg string) (bool, error) {
+	x, bound, err := ruleBound(v, arg)
+	if err != nil {
+		return false, err
+	}
+	return x >= bound, nil
+}
+
+// ruleMax checks the maximum length or value.
+func ruleMax(v reflect.Value, arg string) (bool, error) {
+	x, bound, err := ruleBound(v, arg)
+	if err != nil {
+		return false, err
+	}
+	return x <= bound, nil
+}
+
+// ruleLen checks the exact length of a string or a collection.
+func ruleLen(v reflect.Value, arg string) (bool, error) {
+	n, ok := lengthOf(v)
+	if !ok {
+		return false, errutil.Explain(nil, "type %s isn't supported", v.Type())
+	}
+	want, err := strconv.Atoi(arg)
+	if err != nil {
+		return false, err
+	}
+	return n == want, nil
+}
+
+// rulePattern checks that a string matches a regular expression.
+func rulePattern(v reflect.Value, arg string) (bool, error) {
+	s, ok := stringOf(v)
+	if !ok {
+		return false, errutil.Explain(nil, "type %s isn't supported", v.Type())
+	}
+	r, err := regexp.Compile(arg)
+	if err != nil {
+		return false, err
+	}
+	return s == "" || r.MatchString(s), nil
+}
+
+// ruleOneOf checks that the value is one of the space-separated values.
+func ruleOneOf(v reflect.Value, arg string) (bool, error) {
+	return slices.Contains(strings.Fields(arg), fmt.Sprint(v.Interface())), nil
+}
+
+// ruleEmail checks that a string is a plain email address.
+func ruleEmail(v reflect.Value, _ string) (bool, error) {
+	s, ok := stringOf(v)
+	if !ok {
+		return false, errutil.Explain(nil, "type %s isn't supported", v.Type())
+	}
+	if s == "" {
+		return true, nil
+	}
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s, nil
+}
+
+// ruleHostPort checks that a string is a "host:port" address.
+// The host may be empty, e.g. ":8080".
+func ruleHostPort(v reflect.Value, _ string) (bool, error) {
+	s, ok := stringOf(v)
+	if !ok {
+		return false, errutil.Explain(nil, "type %s isn't supported", v.Type())
+	}
+	if s == "" {
+		return true, nil
+	}
+	_, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return false, nil
+	}
+	n, err := strconv.ParseUint(port, 10, 16)
+	return err == nil && n > 0, nil
+}
+
+// ruleURL checks that a string is an absolute URL with a host.
+func ruleURL(v reflect.Value, _ string) (bool, error) {
+	s, ok := stringOf(v)
+	if !ok {
+		return false, errutil.Explain(nil, "type %s isn't supported", v.Type())
+	}
+	if s == "" {
+		return true, nil
+	}
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && u.Host != "", nil
+}
+
+// stringOf returns the value of a string kind.
+func stringOf(v reflect.Value) (string, bool) {
+	if v.Kind() != reflect.String {
+		return "", false
+	}
+	return v.String(), true
+}
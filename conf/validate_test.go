@@ -0,0 +1,211 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conf_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/stdlib/flatten"
+	"github.com/go-spring/stdlib/testing/assert"
+)
+
+type PoolConfig struct {
+	Name     string        `value:"${name}" validate:"required,pattern=^[a-z]{1\\,8}$"`
+	Mode     string        `value:"${mode:=fifo}" validate:"oneof=fifo lifo"`
+	MinConns int           `value:"${min-conns:=1}" validate:"min=1"`
+	MaxConns int           `value:"${max-conns:=10}" validate:"max=100" expr:"$ >= MinConns"`
+	Idle     time.Duration `value:"${idle:=1m}" validate:"min=1s,max=1h"`
+	MaxIdle  time.Duration `value:"${max-idle:=5m}" validate:"gtefield=Idle"`
+	Hosts    []string      `value:"${hosts:=a}" validate:"min=1,max=3"`
+	Admin    string        `value:"${admin:=}" validate:"email"`
+	Addr     string        `value:"${addr:=:8080}" validate:"hostport"`
+	Endpoint string        `value:"${endpoint:=}" validate:"url"`
+	Code     string        `value:"${code:=ab}" validate:"len=2"`
+	Limit    *int          `value:"${limit}" validate:"min=0"`
+}
+
+func TestValidate(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		var c PoolConfig
+		p := flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"name":      "pool",
+			"max-conns": 20,
+			"admin":     "admin@example.com",
+			"endpoint":  "https://example.com/api",
+			"limit":     5,
+		}))
+		err := conf.Bind(p, &c)
+		assert.That(t, err).Nil()
+		assert.That(t, c.MaxConns).Equal(20)
+		assert.That(t, *c.Limit).Equal(5)
+	})
+
+	testCases := []struct {
+		name  string
+		props map[string]any
+		err   string
+	}{
+		{
+			name:  "required",
+			props: map[string]any{"name": ""},
+			err:   `validate path=PoolConfig.Name type=string error: validate failed on "required" for value `,
+		},
+		{
+			name:  "pattern",
+			props: map[string]any{"name": "Pool"},
+			err:   `validate path=PoolConfig.Name .* validate failed on "pattern=\^\[a-z\]\{1,8\}\$" for value Pool`,
+		},
+		{
+			name:  "oneof",
+			props: map[string]any{"name": "pool", "mode": "random"},
+			err:   `validate path=PoolConfig.Mode .* validate failed on "oneof=fifo lifo" for value random`,
+		},
+		{
+			name:  "min",
+			props: map[string]any{"name": "pool", "min-conns": 0},
+			err:   `validate path=PoolConfig.MinConns .* validate failed on "min=1" for value 0`,
+		},
+		{
+			name:  "max",
+			props: map[string]any{"name": "pool", "max-conns": 200},
+			err:   `validate path=PoolConfig.MaxConns .* validate failed on "max=100" for value 200`,
+		},
+		{
+			name:  "duration bound",
+			props: map[string]any{"name": "pool", "idle": "2h"},
+			err:   `validate path=PoolConfig.Idle .* validate failed on "max=1h" for value 2h0m0s`,
+		},
+		{
+			name:  "slice length",
+			props: map[string]any{"name": "pool", "hosts": "a,b,c,d"},
+			err:   `validate path=PoolConfig.Hosts .* validate failed on "max=3"`,
+		},
+		{
+			name:  "len",
+			props: map[string]any{"name": "pool", "code": "abc"},
+			err:   `validate path=PoolConfig.Code .* validate failed on "len=2" for value abc`,
+		},
+		{
+			name:  "email",
+			props: map[string]any{"name": "pool", "admin": "Admin <admin@example.com>"},
+			err:   `validate path=PoolConfig.Admin .* validate failed on "email"`,
+		},
+		{
+			name:  "hostport",
+			props: map[string]any{"name": "pool", "addr": "localhost"},
+			err:   `validate path=PoolConfig.Addr .* validate failed on "hostport" for value localhost`,
+		},
+		{
+			name:  "url",
+			props: map[string]any{"name": "pool", "endpoint": "example.com"},
+			err:   `validate path=PoolConfig.Endpoint .* validate failed on "url" for value example.com`,
+		},
+		{
+			name:  "pointer",
+			props: map[string]any{"name": "pool", "limit": -1},
+			err:   `validate path=PoolConfig.Limit .* validate failed on "min=0" for value -1`,
+		},
+		{
+			name:  "cross field expr",
+			props: map[string]any{"name": "pool", "min-conns": 5, "max-conns": 2},
+			err:   `validate path=PoolConfig.MaxConns .* validate failed on "\$ >= MinConns" for value 2`,
+		},
+		{
+			name:  "cross field rule",
+			props: map[string]any{"name": "pool", "idle": "10m"},
+			err:   `validate path=PoolConfig.MaxIdle .* validate failed on "gtefield=Idle" for value 5m0s and 10m0s`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var c PoolConfig
+			p := flatten.NewPropertiesStorage(flatten.MapProperties(tc.props))
+			err := conf.Bind(p, &c)
+			assert.Error(t, err).Matches(tc.err)
+		})
+	}
+
+	t.Run("required pointer", func(t *testing.T) {
+		var v struct {
+			Limit *int `value:"${limit}" validate:"required"`
+		}
+		p := flatten.NewPropertiesStorage(flatten.MapProperties(nil))
+		err := conf.Bind(p, &v)
+		assert.Error(t, err).Matches(`validate path=.*\.Limit type=\*int error: validate failed on "required" for value <nil>`)
+	})
+
+	t.Run("unknown rule", func(t *testing.T) {
+		var v struct {
+			A int `value:"${a:=1}" validate:"positive"`
+		}
+		p := flatten.NewPropertiesStorage(flatten.MapProperties(nil))
+		err := conf.Bind(p, &v)
+		assert.Error(t, err).Matches(`unknown validate rule "positive"`)
+	})
+
+	t.Run("unknown sibling", func(t *testing.T) {
+		var v struct {
+			A int `value:"${a:=1}" validate:"gtfield=B"`
+		}
+		p := flatten.NewPropertiesStorage(flatten.MapProperties(nil))
+		err := conf.Bind(p, &v)
+		assert.Error(t, err).Matches(`validate rule "gtfield=B" error: field "B" not found`)
+	})
+
+	t.Run("unexported sibling", func(t *testing.T) {
+		var v struct {
+			A int `value:"${a:=1}" validate:"gtfield=b"`
+			b int
+		}
+		p := flatten.NewPropertiesStorage(flatten.MapProperties(nil))
+		err := conf.Bind(p, &v)
+		assert.Error(t, err).Matches(`validate rule "gtfield=b" error: field "b" is unexported`)
+	})
+
+	t.Run("unexported sibling in expr", func(t *testing.T) {
+		var v struct {
+			A int `value:"${a:=1}" expr:"$ > b"`
+			b int
+		}
+		p := flatten.NewPropertiesStorage(flatten.MapProperties(nil))
+		err := conf.Bind(p, &v)
+		assert.Error(t, err).Matches(`validate expr "\$ > b" error: field "b" is unexported`)
+	})
+
+	t.Run("unknown name in expr", func(t *testing.T) {
+		var v struct {
+			A int `value:"${a:=1}" expr:"$ > Min"`
+		}
+		p := flatten.NewPropertiesStorage(flatten.MapProperties(nil))
+		err := conf.Bind(p, &v)
+		assert.Error(t, err).Matches(`validate expr "\$ > Min" error: unknown name "Min"`)
+	})
+
+	t.Run("bind all", func(t *testing.T) {
+		var c PoolConfig
+		p := flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"name":      "pool",
+			"min-conns": 0,
+			"mode":      "random",
+		}))
+		err := conf.BindAll(p, &c)
+		assert.Error(t, err).Matches(`PoolConfig.Mode .*; .*PoolConfig.MinConns`)
+	})
+}
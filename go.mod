@@ -7,6 +7,7 @@ require (
 	github.com/go-spring/gs-mock v0.0.7
 	github.com/go-spring/log v0.1.0-rc
 	github.com/go-spring/stdlib v0.0.11
+	github.com/hashicorp/hcl v1.0.0
 	github.com/magiconair/properties v1.8.10
 	github.com/pelletier/go-toml v1.9.5
 	github.com/spf13/cast v1.10.0
@@ -21,6 +21,7 @@ import (
 	"os"
 	"os/signal"
 	"reflect"
+	"sync/atomic"
 	"syscall"
 	"testing"
 
@@ -35,6 +36,9 @@ import (
 // inited indicates whether the application has been initialized.
 var inited bool
 
+// currentApp is the application most recently created by Configure.
+var currentApp atomic.Pointer[gs_app.App]
+
 // App defines the configuration interface of a Go-Spring application.
 // Methods on App are only valid during application configuration
 // and must not be called after the application has started.
@@ -59,7 +63,34 @@ type AppStarter struct {
 // function that will be applied before the application starts.
 func Configure(cfg func(App)) *AppStarter {
 	inited = true
-	return &AppStarter{app: gs_app.NewApp(), cfg: cfg}
+	app := gs_app.NewApp()
+	currentApp.Store(app)
+	return &AppStarter{app: app, cfg: cfg}
+}
+
+// PropertyOrigin returns where the effective value of a property of the
+// current application comes from: the value, the source name (a file name,
+// "cmd", "env" or "default") and the values of lower-priority sources that
+// it overrides. The values are resolved, and sensitive values are masked.
+// It returns false if the application has not started or the property is
+// not set.
+func PropertyOrigin(key string) (Origin, bool) {
+	app := currentApp.Load()
+	if app == nil {
+		return Origin{}, false
+	}
+	return app.PropertyOrigin(key)
+}
+
+// PropertyOrigins returns the origins of all properties of the current
+// application, sorted by key. Set spring.app.config.dump=true to log
+// them at startup.
+func PropertyOrigins() []Origin {
+	app := currentApp.Load()
+	if app == nil {
+		return nil
+	}
+	return app.PropertyOrigins()
 }
 
 // startApp starts the application lifecycle by printing the banner,
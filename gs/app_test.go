@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/go-spring/spring-core/gs"
+	"github.com/go-spring/stdlib/testing/assert"
 )
 
 func init() {
@@ -55,3 +56,35 @@ func TestApp2(t *testing.T) {
 		fmt.Println(s.Name, s.Svr.Name, s.App1)
 	})
 }
+
+func TestPropertyOrigin(t *testing.T) {
+	gs.Configure(func(g gs.App) {
+		g.Property("name", "myapp3")
+	}).RunTest(t, func(s *struct {
+		Name string `value:"${name}"`
+	}) {
+		o, ok := gs.PropertyOrigin("name")
+		assert.That(t, ok).True()
+		assert.That(t, o.Value).Equal("myapp3")
+		assert.That(t, o.Source).Equal("default")
+		_, ok = gs.PropertyOrigin("not.exist")
+		assert.That(t, ok).False()
+	})
+}
+
+func TestBeanFactory(t *testing.T) {
+	gs.RunTest(t, func(s *struct {
+		Beans gs.BeanFactory `autowire:""`
+	}) {
+		svr, err := gs.Get[*GlobalService](s.Beans)
+		assert.That(t, err).Nil()
+		assert.That(t, svr.Name).Equal("global")
+
+		all, err := gs.GetAll[*GlobalService](s.Beans)
+		assert.That(t, err).Nil()
+		assert.That(t, all).Equal([]*GlobalService{svr})
+
+		_, err = gs.Get[*GlobalService](s.Beans, "not.exist")
+		assert.Error(t, err).Matches("can't find bean")
+	})
+}
@@ -27,8 +27,11 @@ import (
 	"github.com/go-spring/spring-core/gs/internal/gs_arg"
 	"github.com/go-spring/spring-core/gs/internal/gs_bean"
 	"github.com/go-spring/spring-core/gs/internal/gs_cond"
+	"github.com/go-spring/spring-core/gs/internal/gs_conf"
+	"github.com/go-spring/spring-core/gs/internal/gs_core/injecting"
 	"github.com/go-spring/spring-core/gs/internal/gs_dync"
 	"github.com/go-spring/spring-core/gs/internal/gs_init"
+	"github.com/go-spring/spring-core/gs/internal/gs_meta"
 	"github.com/go-spring/stdlib/flatten"
 )
 
@@ -44,6 +47,89 @@ type BeanID = gs.BeanID
 // It represents a property that can change at runtime.
 type Dync[T any] = gs_dync.Value[T]
 
+// Origin describes where the effective value of a property comes from,
+// and which values of lower-priority sources it overrides,
+// see PropertyOrigin.
+type Origin = gs_conf.PropertyOrigin
+
+// SourceValue is the value of a property in one configuration source.
+type SourceValue = gs_conf.SourceValue
+
+// BeanScope determines how many instances of a bean are created.
+type BeanScope = gs.BeanScope
+
+const (
+	ScopeSingleton = gs.ScopeSingleton // One instance for the container
+	ScopePrototype = gs.ScopePrototype // A new instance for each injection point
+	ScopeContext   = gs.ScopeContext   // One instance for each context.Context
+)
+
+// ScopedProvider gets the instance of a context scoped bean for a context.
+// A field of this type is injected with the bean instead of the bean itself:
+//
+//	type Handler struct {
+//	    Session gs.ScopedProvider[*Session] `autowire:""`
+//	}
+//
+// The instance is created on the first Get for a context and destroyed
+// when the context is done.
+type ScopedProvider[T any] = injecting.ScopedProvider[T]
+
+// Provider gets a bean, or the beans for Provider[[]T], on the first call
+// of Get. A field of this type is injected with the handle instead of the
+// bean, with the same tag, e.g.
+//
+//	type Service struct {
+//	    Repo gs.Provider[*Repository] `autowire:""`
+//	}
+//
+// which breaks circular dependencies and delays the creation of beans
+// that are rarely used. Get is safe for concurrent use.
+type Provider[T any] = injecting.Provider[T]
+
+// Ordered is implemented by the beans that choose their position among
+// the beans injected into a slice, e.g. middlewares, runners and servers.
+// Lower orders come first, and BeanDefinition.Order takes precedence.
+type Ordered = gs.Ordered
+
+// BeanInfo is the read-only definition of a bean in the container.
+type BeanInfo = gs.BeanInfo
+
+// BeanFactory looks up the beans of the container after refresh, e.g. for
+// plugins or admin handlers. A field, or constructor argument, of this type
+// is injected with the container's factory:
+//
+//	type Admin struct {
+//	    Beans gs.BeanFactory `autowire:""`
+//	}
+//
+// The container keeps the definitions of the beans after refresh only if
+// a BeanFactory is injected. The lookups are safe for concurrent use.
+type BeanFactory = gs.BeanFactory
+
+// Get returns the bean of type T from the factory, with the name if given.
+func Get[T any](f BeanFactory, name ...string) (T, error) {
+	var r T
+	v, err := f.Get(reflect.TypeFor[T](), name...)
+	if err != nil {
+		return r, err
+	}
+	return v.(T), nil
+}
+
+// GetAll returns all the beans of type T from the factory.
+func GetAll[T any](f BeanFactory) ([]T, error) {
+	values, err := f.GetAll(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]T, 0, len(values))
+	for _, v := range values {
+		ret = append(ret, v.(T))
+	}
+	return ret, nil
+}
+
 // As returns the [reflect.Type] of an interface T.
 // T is expected to be an interface type.
 func As[T any]() reflect.Type {
@@ -119,6 +205,7 @@ func OnProperty(name string) PropertyCondition {
 }
 
 // OnBean requires that a bean of the given type (and optional name) exists.
+// The name may be "@qualifier" to select the beans with the qualifier.
 func OnBean[T any](name ...string) Condition {
 	return gs_cond.OnBean[T](name...)
 }
@@ -216,6 +303,7 @@ func Group[T any, R any](tag string, fn func(c T) (R, error), d func(R) error) {
 	}
 	_, file, line, _ := runtime.Caller(1)
 	key := strings.TrimSuffix(strings.TrimPrefix(tag, "${"), "}")
+	gs_init.AddGroup(key, reflect.TypeFor[T](), reflect.TypeFor[R](), file, line)
 	gs_init.AddModule(OnProperty(key), func(r BeanProvider, p flatten.Storage) error {
 		var m map[string]T
 		if err := conf.Bind(p, &m, "${"+key+"}"); err != nil {
@@ -231,3 +319,31 @@ func Group[T any, R any](tag string, fn func(c T) (R, error), d func(R) error) {
 		return nil
 	}, file, line)
 }
+
+/********************************* metadata **********************************/
+
+// Metadata is the configuration metadata of an application.
+type Metadata = gs_meta.Metadata
+
+// ConfigMetadata returns the metadata of the properties read by the beans
+// registered with Provide, by their `value` fields and TagArg constructor
+// arguments, and by the groups registered with Group.
+func ConfigMetadata() (*Metadata, error) {
+	return gs_meta.Collect(gs_init.Beans(), gs_init.Groups())
+}
+
+// WriteConfigMetadata writes the configuration metadata into the directory,
+// as configuration-metadata.json in the format of Spring's configuration
+// metadata and as configuration-schema.json, a JSON Schema.
+//
+// It is run by the gs-metadata command, which imports the packages
+// registering the beans, e.g. with the directive in main.go:
+//
+//	//go:generate go run github.com/go-spring/spring-core/cmd/gs-metadata ./service
+func WriteConfigMetadata(dir string) error {
+	m, err := ConfigMetadata()
+	if err != nil {
+		return err
+	}
+	return m.WriteFiles(dir)
+}
@@ -76,6 +76,64 @@ func (s BeanID) String() string {
 	return sb.String()
 }
 
+// BeanScope determines how many instances of a bean are created.
+type BeanScope int8
+
+const (
+	// ScopeSingleton creates one instance of the bean for the container.
+	ScopeSingleton = BeanScope(iota)
+	// ScopePrototype creates a new instance for each injection point.
+	ScopePrototype
+	// ScopeContext creates one instance for each [context.Context],
+	// e.g. a request or a job. It's destroyed when the context is done.
+	ScopeContext
+)
+
+// String returns a human-readable string for the bean scope.
+func (s BeanScope) String() string {
+	switch s {
+	case ScopeSingleton:
+		return "singleton"
+	case ScopePrototype:
+		return "prototype"
+	case ScopeContext:
+		return "context"
+	default:
+		return "unknown"
+	}
+}
+
+// Ordered is implemented by the beans that choose their position among
+// the beans injected into a slice. Lower orders come first.
+type Ordered interface {
+	Order() int
+}
+
+// BeanInfo is the read-only definition of a bean in the container.
+type BeanInfo interface {
+	ConditionBean
+	GetScope() BeanScope     // Returns the bean's scope
+	IsPrimary() bool         // Returns whether the bean is primary
+	GetQualifiers() []string // Returns the bean's qualifiers
+	GetOrder() int           // Returns the bean's order in slices
+	Exports() []reflect.Type // Returns the interfaces exported by the bean
+	FileLine() string        // Returns the source location of the bean
+}
+
+// BeanFactory looks up the beans of the container after refresh.
+// It's injected into the fields, or constructor arguments, of its type.
+type BeanFactory interface {
+	// Get returns the bean of the type, with the name if given, wiring it
+	// if needed. A prototype bean gets a new instance each time.
+	Get(t reflect.Type, name ...string) (any, error)
+	// GetAll returns all the beans of the type, wiring them if needed.
+	GetAll(t reflect.Type) ([]any, error)
+	// Names returns the names of all the beans, sorted.
+	Names() []string
+	// Definition returns the definition of the bean that matches the ID.
+	Definition(id BeanID) (BeanInfo, error)
+}
+
 /************************************ cond ***********************************/
 
 // ConditionBean represents a bean in the IoC container that can be queried by conditions.
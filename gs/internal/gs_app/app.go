@@ -20,9 +20,15 @@ package gs_app
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-spring/log"
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/conf/provider"
 	"github.com/go-spring/spring-core/gs/internal/gs"
 	"github.com/go-spring/spring-core/gs/internal/gs_bean"
 	"github.com/go-spring/spring-core/gs/internal/gs_conf"
@@ -79,10 +85,20 @@ type App struct {
 	cancel context.CancelFunc // Function to cancel the root context
 	wg     sync.WaitGroup     // WaitGroup to track running servers
 
+	// Runners and Servers are run in the order of the beans, see gs.Ordered,
+	// unless the properties list their names.
 	Runners []Runner `autowire:"${spring.app.runners:=?}"`
 	Servers []Server `autowire:"${spring.app.servers:=?}"`
 
 	roots []*gs_bean.BeanDefinition // Root beans for container refresh
+
+	origins atomic.Pointer[gs_conf.PropertySources] // Sources of the last refresh
+
+	refreshMutex sync.Mutex                    // Serializes property refreshes
+	watchMutex   sync.Mutex                    // Guards the fields below
+	watched      map[string]context.CancelFunc // Sources being watched
+	debounce     time.Duration                 // Delay before a change is applied
+	timer        *time.Timer                   // Pending debounced refresh
 }
 
 // NewApp creates a new App instance with an initialized root context.
@@ -90,10 +106,11 @@ func NewApp() *App {
 	ctx := context.WithValue(context.Background(), "app", "")
 	ctx, cancel := context.WithCancel(ctx)
 	return &App{
-		c:      gs_core.New(),
-		p:      gs_conf.NewAppConfig(),
-		ctx:    ctx,
-		cancel: cancel,
+		c:       gs_core.New(),
+		p:       gs_conf.NewAppConfig(),
+		ctx:     ctx,
+		cancel:  cancel,
+		watched: make(map[string]context.CancelFunc),
 	}
 }
 
@@ -121,14 +138,184 @@ func (app *App) Root(b *gs_bean.BeanDefinition) *gs_bean.BeanDefinition {
 	return b
 }
 
+// PropertyOrigin returns where the effective value of a property comes from,
+// and the values it overrides. It returns false before the application is
+// started or if the property is not set.
+func (app *App) PropertyOrigin(key string) (gs_conf.PropertyOrigin, bool) {
+	s := app.origins.Load()
+	if s == nil {
+		return gs_conf.PropertyOrigin{}, false
+	}
+	return s.Origin(key)
+}
+
+// PropertyOrigins returns the origins of all properties, sorted by key.
+func (app *App) PropertyOrigins() []gs_conf.PropertyOrigin {
+	s := app.origins.Load()
+	if s == nil {
+		return nil
+	}
+	return s.Origins()
+}
+
+// dumpProperties logs the origin of every property if the property
+// spring.app.config.dump is true. Sensitive values are masked.
+func (app *App) dumpProperties(p flatten.Storage) error {
+	var c struct {
+		Dump bool `value:"${spring.app.config.dump:=false}"`
+	}
+	if err := conf.Bind(p, &c); err != nil {
+		return err
+	}
+	if !c.Dump {
+		return nil
+	}
+	for _, o := range app.PropertyOrigins() {
+		var sb strings.Builder
+		for i, v := range o.Overridden {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(v.Value + " from " + v.Source)
+		}
+		if sb.Len() == 0 {
+			log.Infof(app.ctx, log.TagAppDef, "property %s=%s from %s", o.Key, o.Value, o.Source)
+		} else {
+			log.Infof(app.ctx, log.TagAppDef, "property %s=%s from %s (overrides %s)", o.Key, o.Value, o.Source, sb.String())
+		}
+	}
+	return nil
+}
+
+// strictConfig holds the options of strict binding.
+type strictConfig struct {
+	Mode  string   `value:"${spring.config.strict:=false}"`
+	Allow []string `value:"${spring.config.strict-allow:=}"`
+}
+
+// strictMode reads the options of strict binding. The mode "true"
+// is the same as "error".
+func (app *App) strictMode(p flatten.Storage) (strictConfig, error) {
+	var c strictConfig
+	if err := conf.Bind(p, &c); err != nil {
+		return c, err
+	}
+	switch c.Mode = strings.ToLower(c.Mode); c.Mode {
+	case "", gs_conf.StrictOff:
+		c.Mode = gs_conf.StrictOff
+	case "true", gs_conf.StrictError:
+		c.Mode = gs_conf.StrictError
+	case gs_conf.StrictWarn:
+	default:
+		return c, errutil.Explain(nil, "invalid spring.config.strict %q", c.Mode)
+	}
+	return c, nil
+}
+
+// checkUnusedKeys reports the keys of app and profile files that nothing
+// consumed while the container was refreshed, as warnings or as an error
+// depending on spring.config.strict. Keys under the prefixes listed in
+// spring.config.strict-allow are exempt.
+func (app *App) checkUnusedKeys(r *gs_conf.KeyRecorder, c strictConfig) error {
+	s := app.origins.Load()
+	keys := s.UnusedKeys(r, c.Allow)
+	if len(keys) == 0 {
+		return nil
+	}
+	for i, k := range keys {
+		if o, ok := s.Origin(k); ok {
+			keys[i] = k + " (" + o.Source + ")"
+		}
+	}
+	if c.Mode == gs_conf.StrictWarn {
+		for _, k := range keys {
+			log.Warnf(app.ctx, log.TagAppDef, "unused property %s", k)
+		}
+		return nil
+	}
+	return errutil.Explain(nil, "unused properties: %s", strings.Join(keys, ", "))
+}
+
 // RefreshProperties reloads application properties from all sources
 // and propagates the changes to the IoC container.
 func (app *App) RefreshProperties() error {
+	app.refreshMutex.Lock()
+	defer app.refreshMutex.Unlock()
 	p, err := app.p.Refresh()
 	if err != nil {
 		return err
 	}
-	return app.c.RefreshProperties(p)
+	app.origins.Store(app.p.PropertySources())
+	if err = app.c.RefreshProperties(p); err != nil {
+		return err
+	}
+	return app.watchSources(p)
+}
+
+// watchSources subscribes to every configuration source of the last
+// refresh whose provider supports watching, including the candidate files
+// that don't exist yet. Changes are debounced and then applied through
+// RefreshProperties. The watch set is rebuilt on each refresh, so sources
+// no longer loaded stop being watched. Watching is off unless
+// spring.app.config.watch is set to true.
+func (app *App) watchSources(p flatten.Storage) error {
+	var c struct {
+		Watch    bool          `value:"${spring.app.config.watch:=false}"`
+		Debounce time.Duration `value:"${spring.app.config.debounce:=500ms}"`
+	}
+	if err := conf.Bind(p, &c); err != nil {
+		return err
+	}
+
+	var sources []string
+	if c.Watch {
+		sources = app.p.WatchSources()
+	}
+
+	app.watchMutex.Lock()
+	defer app.watchMutex.Unlock()
+	app.debounce = c.Debounce
+	for source, cancel := range app.watched {
+		if !slices.Contains(sources, source) {
+			cancel()
+			delete(app.watched, source)
+		}
+	}
+	for _, source := range sources {
+		if _, ok := app.watched[source]; ok {
+			continue
+		}
+		ctx, cancel := context.WithCancel(app.ctx)
+		ok, err := provider.Watch(ctx, source, app.onSourceChange)
+		if err != nil {
+			cancel()
+			return errutil.Explain(err, "watch source %s error", source)
+		}
+		if !ok {
+			cancel()
+			continue
+		}
+		app.watched[source] = cancel
+	}
+	return nil
+}
+
+// onSourceChange schedules a property refresh, restarting the debounce
+// delay if a refresh is already pending.
+func (app *App) onSourceChange() {
+	app.watchMutex.Lock()
+	defer app.watchMutex.Unlock()
+	if app.timer != nil {
+		app.timer.Stop()
+	}
+	app.timer = time.AfterFunc(app.debounce, func() {
+		if app.ctx.Err() != nil {
+			return
+		}
+		if err := app.RefreshProperties(); err != nil {
+			log.Errorf(app.ctx, log.TagAppDef, "refresh properties error: %v", err)
+		}
+	})
 }
 
 // initLog initializes the application's logging system.
@@ -144,10 +331,12 @@ func (app *App) initLog(p flatten.Storage) error {
 // Start initializes and launches the application.
 // The startup sequence is:
 //  1. Refresh application properties from all sources
-//  2. Initialize logging system
+//  2. Initialize logging system, and dump the property origins if requested
 //  3. Register the App, ContextProvider, and PropertiesRefresher beans in the container
-//  4. Refresh the IoC container to wire all beans
-//  5. Clear the temporary root bean list after container refresh
+//  4. Refresh the IoC container to wire all beans, and report the keys of
+//     config files that nothing consumed if spring.config.strict is set
+//  5. Clear the temporary root bean list after container refresh, and watch
+//     the configuration sources if there are dynamic objects to refresh
 //  6. Execute all Runner beans sequentially
 //  7. Start all configured servers in separate goroutines
 //     - Each server signals readiness via ReadySignal
@@ -165,20 +354,46 @@ func (app *App) Start() error {
 	if err != nil {
 		return err
 	}
+	app.origins.Store(app.p.PropertySources())
+
+	// Record the consumed keys if strict binding is enabled
+	strict, err := app.strictMode(p)
+	if err != nil {
+		return err
+	}
+	var recorder *gs_conf.KeyRecorder
+	if strict.Mode != gs_conf.StrictOff {
+		recorder = gs_conf.NewKeyRecorder(p)
+		p = recorder
+	}
 
 	// Initialize logger
 	if err = app.initLog(p); err != nil {
 		return err
 	}
 
+	// Report the origin of every property if requested
+	if err = app.dumpProperties(p); err != nil {
+		return err
+	}
+
 	// Refresh IoC container to wire all beans
 	if err = app.c.Refresh(p, app.roots); err != nil {
 		return err
 	}
 
+	// Report the keys of config files that nothing consumed
+	if recorder != nil {
+		if err = app.checkUnusedKeys(recorder, strict); err != nil {
+			return err
+		}
+	}
+
 	app.roots = nil
 	if app.c.DynamicObjectsCount() == 0 {
 		app.p = nil
+	} else if err = app.watchSources(p); err != nil {
+		return err
 	}
 
 	// Execute all Runner beans sequentially
@@ -20,12 +20,15 @@ import (
 	"bytes"
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/go-spring/gs-mock/gsmock"
 	"github.com/go-spring/log"
+	"github.com/go-spring/spring-core/conf/provider"
 	"github.com/go-spring/spring-core/gs/internal/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_dync"
 	"github.com/go-spring/stdlib/errutil"
 	"github.com/go-spring/stdlib/goutil"
 	"github.com/go-spring/stdlib/testing/assert"
@@ -246,4 +249,133 @@ func TestApp(t *testing.T) {
 		time.Sleep(50 * time.Millisecond)
 		assert.String(t, logBuf.String()).Contains("shutdown server failed: server shutdown error")
 	})
+
+	t.Run("refresh on source change", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		interval := provider.WatchInterval
+		provider.WatchInterval = 10 * time.Millisecond
+		t.Cleanup(func() { provider.WatchInterval = interval })
+
+		dir := t.TempDir()
+		file := filepath.Join(dir, "app.properties")
+		err := os.WriteFile(file, []byte("addr=127.0.0.1:8080"), 0644)
+		assert.That(t, err).Nil()
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_DIR", dir)
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_WATCH", "true")
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_DEBOUNCE", "10ms")
+
+		var c struct {
+			Addr gs_dync.Value[string] `value:"${addr}"`
+		}
+
+		app := NewApp()
+		app.Root(app.c.Provide(&c))
+		err = app.Start()
+		assert.That(t, err).Nil()
+		assert.That(t, c.Addr.Value()).Equal("127.0.0.1:8080")
+
+		err = os.WriteFile(file, []byte("addr=127.0.0.1:9090"), 0644)
+		assert.That(t, err).Nil()
+		time.Sleep(200 * time.Millisecond)
+		assert.That(t, c.Addr.Value()).Equal("127.0.0.1:9090")
+
+		app.ShutDown()
+		app.WaitForShutdown()
+	})
+
+	t.Run("watch sources", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		interval := provider.WatchInterval
+		provider.WatchInterval = 10 * time.Millisecond
+		t.Cleanup(func() { provider.WatchInterval = interval })
+
+		dir := t.TempDir()
+		file := filepath.Join(dir, "app.properties")
+		imported := filepath.Join(dir, "imported.properties")
+		err := os.WriteFile(file, []byte("spring.app.imports="+imported), 0644)
+		assert.That(t, err).Nil()
+		err = os.WriteFile(imported, []byte("addr=127.0.0.1:8080"), 0644)
+		assert.That(t, err).Nil()
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_DIR", dir)
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_DEBOUNCE", "10ms")
+
+		var c struct {
+			Addr gs_dync.Value[string] `value:"${addr:=}"`
+		}
+
+		app := NewApp()
+		app.Root(app.c.Provide(&c))
+		err = app.Start()
+		assert.That(t, err).Nil()
+		assert.That(t, len(app.watched)).Equal(0)
+
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_WATCH", "true")
+		err = app.RefreshProperties()
+		assert.That(t, err).Nil()
+		_, ok := app.watched[imported]
+		assert.That(t, ok).True()
+		_, ok = app.watched[filepath.Join(dir, "app.yaml")]
+		assert.That(t, ok).True()
+
+		// a file missing at startup is watched
+		err = os.WriteFile(filepath.Join(dir, "app.yaml"), []byte("addr: 127.0.0.1:9090"), 0644)
+		assert.That(t, err).Nil()
+		time.Sleep(200 * time.Millisecond)
+		assert.That(t, c.Addr.Value()).Equal("127.0.0.1:9090")
+
+		// a removed import is no longer watched
+		err = os.WriteFile(file, nil, 0644)
+		assert.That(t, err).Nil()
+		time.Sleep(200 * time.Millisecond)
+		_, ok = app.watched[imported]
+		assert.That(t, ok).False()
+
+		app.ShutDown()
+		app.WaitForShutdown()
+	})
+
+	t.Run("strict unused keys", func(t *testing.T) {
+		Reset()
+		t.Cleanup(Reset)
+
+		dir := t.TempDir()
+		file := filepath.Join(dir, "app.properties")
+		err := os.WriteFile(file, []byte("server.addr=:8080\nserver.adress=:9090\nextra.a=1"), 0644)
+		assert.That(t, err).Nil()
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_DIR", dir)
+		_ = os.Setenv("GS_SPRING_CONFIG_STRICT", "true")
+
+		var c struct {
+			Addr string `value:"${server.addr}"`
+		}
+
+		app := NewApp()
+		app.Root(app.c.Provide(&c))
+		err = app.Start()
+		assert.Error(t, err).Matches(`unused properties: extra.a \(.*app.properties\), server.adress \(.*app.properties\)`)
+
+		app = NewApp()
+		app.Property("spring.config.strict-allow", "extra")
+		app.Root(app.c.Provide(&c))
+		err = app.Start()
+		assert.Error(t, err).Matches(`unused properties: server.adress \(.*app.properties\)$`)
+
+		_ = os.Setenv("GS_SPRING_CONFIG_STRICT", "warn")
+		app = NewApp()
+		app.Root(app.c.Provide(&c))
+		err = app.Start()
+		assert.That(t, err).Nil()
+		assert.That(t, c.Addr).Equal(":8080")
+		app.ShutDown()
+		app.WaitForShutdown()
+
+		_ = os.Setenv("GS_SPRING_CONFIG_STRICT", "yes")
+		app = NewApp()
+		err = app.Start()
+		assert.Error(t, err).Matches(`invalid spring.config.strict "yes"`)
+	})
 }
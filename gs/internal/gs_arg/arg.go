@@ -251,6 +251,18 @@ func NewCallable(fn CallableFunc, args []gs.Arg) (*Callable, error) {
 	return &Callable{fn: fn, argList: argList}, nil
 }
 
+// Type returns the type of the underlying function.
+func (r *Callable) Type() reflect.Type {
+	return r.argList.fnType
+}
+
+// Args returns the arguments of the underlying function, one for each
+// fixed parameter followed by the variadic ones. Parameters without a
+// given argument have an empty TagArg.
+func (r *Callable) Args() []gs.Arg {
+	return r.argList.args
+}
+
 // Call resolves all arguments and invokes the underlying function.
 func (r *Callable) Call(ctx gs.ArgContext) ([]reflect.Value, error) {
 	ret, err := r.argList.get(ctx)
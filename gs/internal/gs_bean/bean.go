@@ -82,6 +82,11 @@ type BeanDefinition struct {
 	dependsOn     []gs.BeanID      // Explicit dependencies of the bean
 	exports       []reflect.Type   // Interfaces exported by this bean
 	conditions    []gs.Condition   // Conditions controlling bean creation
+	scope         gs.BeanScope     // Number of instances of the bean
+	primary       bool             // Whether the bean wins single-valued injection
+	qualifiers    []string         // Labels selecting the bean, e.g. "@readonly"
+	order         int              // Position of the bean in slices
+	ordered       bool             // Whether the order is set
 	status        BeanStatus       // Current lifecycle status
 	fileLine      string           // File and line where bean is defined
 	configuration *Configuration   // Configuration for sub/child beans
@@ -104,6 +109,18 @@ func (d *BeanDefinition) Clone() *BeanDefinition {
 	return &r
 }
 
+// NewInstance creates a definition for another instance of a prototype
+// or context scoped bean. Constructor beans call the constructor again,
+// and object beans get a shallow copy of the registered object.
+func (d *BeanDefinition) NewInstance() *BeanDefinition {
+	r := d.Clone()
+	if d.f == nil && d.t.Kind() == reflect.Pointer {
+		r.v.Elem().Set(d.v.Elem())
+	}
+	r.status = StatusResolved
+	return r
+}
+
 // validLifeCycleFunc checks if the given function is a valid lifecycle function.
 // Valid lifecycle functions must have the signature:
 //
@@ -159,6 +176,85 @@ func (d *BeanDefinition) Condition(conditions ...gs.Condition) *BeanDefinition {
 	return d
 }
 
+// GetScope returns the scope of the bean.
+func (d *BeanDefinition) GetScope() gs.BeanScope {
+	return d.scope
+}
+
+// Scope sets the scope of the bean, see [gs.BeanScope].
+func (d *BeanDefinition) Scope(scope gs.BeanScope) *BeanDefinition {
+	d.scope = scope
+	return d
+}
+
+// IsPrimary returns whether the bean is primary.
+func (d *BeanDefinition) IsPrimary() bool {
+	return d.primary
+}
+
+// Primary marks the bean as primary, so that it's injected into a
+// single-valued field or argument when several beans match.
+func (d *BeanDefinition) Primary() *BeanDefinition {
+	d.primary = true
+	return d
+}
+
+// GetQualifiers returns the qualifiers of the bean.
+func (d *BeanDefinition) GetQualifiers() []string {
+	return d.qualifiers
+}
+
+// Qualifier adds qualifiers to the bean, which injection tags and
+// conditions select with a '@' prefix, e.g. `autowire:"@readonly"`.
+func (d *BeanDefinition) Qualifier(qualifiers ...string) *BeanDefinition {
+	for _, q := range qualifiers {
+		if q == "" {
+			panic("qualifier can't be empty")
+		}
+		if !slices.Contains(d.qualifiers, q) {
+			d.qualifiers = append(d.qualifiers, q)
+		}
+	}
+	return d
+}
+
+// MatchName reports whether the bean is selected by a name, which is
+// either the bean's name or one of its qualifiers prefixed with '@'.
+func (d *BeanDefinition) MatchName(name string) bool {
+	if q, ok := strings.CutPrefix(name, "@"); ok {
+		return slices.Contains(d.qualifiers, q)
+	}
+	return name == d.name
+}
+
+// GetOrder returns the order of the bean, set by Order, or else by the
+// bean itself if it implements [gs.Ordered] and has been created.
+// The order is 0 by default.
+func (d *BeanDefinition) GetOrder() int {
+	if d.ordered {
+		return d.order
+	}
+	if d.f != nil && d.status < StatusCreated {
+		return 0 // not created yet
+	}
+	if !d.v.IsValid() || (d.v.Kind() == reflect.Pointer && d.v.IsNil()) {
+		return 0
+	}
+	if o, ok := d.v.Interface().(gs.Ordered); ok {
+		return o.Order()
+	}
+	return 0
+}
+
+// Order sets the order of the bean among the beans injected into a
+// slice. Lower orders come first, and beans of the same order are
+// sorted by name.
+func (d *BeanDefinition) Order(order int) *BeanDefinition {
+	d.order = order
+	d.ordered = true
+	return d
+}
+
 // GetConfiguration returns the configuration for the bean.
 func (d *BeanDefinition) GetConfiguration() *Configuration {
 	return d.configuration
@@ -91,6 +91,41 @@ func TestBeanDefinition(t *testing.T) {
 		assert.That(t, bean.GetDependsOn()).Equal([]gs.BeanID{selector})
 	})
 
+	t.Run("scope", func(t *testing.T) {
+		a := &TestBean{dummy: 3}
+		bean := NewBean(a).Scope(gs.ScopePrototype)
+		assert.That(t, bean.GetScope()).Equal(gs.ScopePrototype)
+		bean.SetStatus(StatusWired)
+
+		i := bean.NewInstance()
+		assert.That(t, i.GetScope()).Equal(gs.ScopePrototype)
+		assert.That(t, i.Status()).Equal(StatusResolved)
+		assert.That(t, i.Interface() != any(a)).True()
+		assert.That(t, i.Interface().(*TestBean).Dummy()).Equal(3)
+	})
+
+	t.Run("primary and qualifier", func(t *testing.T) {
+		bean := NewBean(&TestBean{}).Name("test")
+		assert.That(t, bean.IsPrimary()).False()
+		bean.Primary().Qualifier("readonly", "replica").Qualifier("readonly")
+		assert.That(t, bean.IsPrimary()).True()
+		assert.That(t, bean.GetQualifiers()).Equal([]string{"readonly", "replica"})
+		assert.That(t, bean.MatchName("test")).True()
+		assert.That(t, bean.MatchName("@replica")).True()
+		assert.That(t, bean.MatchName("readonly")).False()
+		assert.That(t, bean.MatchName("@test")).False()
+		assert.Panic(t, func() {
+			bean.Qualifier("")
+		}, "qualifier can't be empty")
+	})
+
+	t.Run("order", func(t *testing.T) {
+		bean := NewBean(&TestBean{}).Name("test")
+		assert.That(t, bean.GetOrder()).Equal(0)
+		bean.Order(-5)
+		assert.That(t, bean.GetOrder()).Equal(-5)
+	})
+
 	t.Run("init function", func(t *testing.T) {
 		v := reflect.ValueOf(&TestBean{})
 		bean := makeBean(v.Type(), v, nil, "test")
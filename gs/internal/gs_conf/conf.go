@@ -24,21 +24,31 @@
 //   - Operating system environment variables
 //   - Imports declared in profile configuration files (spring.app.imports)
 //   - Profile-specific configuration files (e.g., ./conf/app-dev.yaml)
+//   - Profile-gated documents in local yaml files (spring.config.activate.on-profile)
 //   - Imports declared in application configuration files (spring.app.imports)
 //   - Local configuration files (e.g., ./conf/app.yaml)
 //   - Built-in default properties
 //
 // Configuration sources are applied in layers, and later layers override
 // earlier ones when the same key appears multiple times.
+//
+// With spring.config.strict set to "warn" or "error" ("true"), the keys of
+// local and profile files that nothing consumes at startup are reported,
+// see KeyRecorder. Prefixes listed in spring.config.strict-allow are exempt.
 package gs_conf
 
 import (
 	"errors"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/conf/provider"
+	"github.com/go-spring/spring-core/conf/reader/yaml"
 	"github.com/go-spring/stdlib/errutil"
 	"github.com/go-spring/stdlib/flatten"
 )
@@ -46,6 +56,9 @@ import (
 // AppConfig represents the layered configuration of an application.
 type AppConfig struct {
 	Properties *flatten.Properties
+	sources    []string         // sources loaded by the last refresh
+	missing    []string         // candidate files missing in the last refresh
+	origins    *PropertySources // sources of the last refresh, by layer
 }
 
 // NewAppConfig creates a new AppConfig instance.
@@ -55,8 +68,61 @@ func NewAppConfig() *AppConfig {
 	}
 }
 
+// Sources returns the configuration sources loaded by the last refresh,
+// in the format accepted by conf.Load, so that they can be watched.
+// The sources are named by provider.SourceName, without credentials.
+func (c *AppConfig) Sources() []string {
+	return checkDuplicates(c.sources)
+}
+
+// WatchSources returns the sources to watch after the last refresh: the
+// loaded sources and the candidate configuration files that don't exist
+// yet, so that creating one of them is noticed as well.
+func (c *AppConfig) WatchSources() []string {
+	return checkDuplicates(slices.Concat(c.sources, c.missing))
+}
+
+// PropertySources returns the property sources of the last refresh,
+// which report the origin of every property.
+func (c *AppConfig) PropertySources() *PropertySources {
+	return c.origins
+}
+
+// layeredStorage is a flatten.LayeredStorage that reports its layers to
+// relaxed binding, see conf.LayeredStorage.
+type layeredStorage struct {
+	*flatten.LayeredStorage
+	sources [flatten.StorageMax][]conf.Layer
+	layers  []conf.Layer // sources from higher to lower precedence
+}
+
+// add adds a source to a layer; like flatten.LayeredStorage.AddStorage,
+// sources added later take precedence within the same layer.
+func (l *layeredStorage) add(layer int, p *flatten.Properties, name string) {
+	s := flatten.NewPropertiesStorage(p)
+	l.AddStorage(layer, s, name)
+	env := layer == flatten.StorageEnvironment
+	l.sources[layer] = append([]conf.Layer{{Storage: s, Env: env}}, l.sources[layer]...)
+	l.layers = slices.Concat(l.sources[:]...)
+}
+
+// Layers returns the sources of the storage, from higher to lower precedence.
+func (l *layeredStorage) Layers() []conf.Layer {
+	return l.layers
+}
+
+// addStorage adds a source to the layered storage and records it.
+func (c *AppConfig) addStorage(l *layeredStorage, layer int, p *flatten.Properties, name string) {
+	l.add(layer, p, name)
+	c.origins.add(layer, p, name)
+}
+
 // Refresh refreshes the configuration by merging multiple sources.
 func (c *AppConfig) Refresh() (flatten.Storage, error) {
+	c.sources = nil
+	c.missing = nil
+	c.origins = &PropertySources{}
+
 	cmd, err := extractCmdArgs()
 	if err != nil {
 		return nil, err
@@ -67,17 +133,17 @@ func (c *AppConfig) Refresh() (flatten.Storage, error) {
 		return nil, err
 	}
 
-	l := &flatten.LayeredStorage{}
-	l.AddStorage(flatten.StorageCommandLine, flatten.NewPropertiesStorage(cmd), "cmd")
-	l.AddStorage(flatten.StorageEnvironment, flatten.NewPropertiesStorage(env), "env")
-	l.AddStorage(flatten.StorageDefault, flatten.NewPropertiesStorage(c.Properties), "")
+	l := &layeredStorage{LayeredStorage: &flatten.LayeredStorage{}}
+	c.addStorage(l, flatten.StorageCommandLine, cmd, "cmd")
+	c.addStorage(l, flatten.StorageEnvironment, env, "env")
+	c.addStorage(l, flatten.StorageDefault, c.Properties, "")
 
 	confDir, err := conf.Resolve(l, "${spring.app.config.dir:=./conf}")
 	if err != nil {
 		return nil, err
 	}
 
-	if err = loadFiles(l, confDir, nil); err != nil {
+	if err = c.loadFiles(l, confDir, nil); err != nil {
 		return nil, errutil.Stack(err, "refresh error in source local")
 	}
 
@@ -88,9 +154,20 @@ func (c *AppConfig) Refresh() (flatten.Storage, error) {
 	}
 	activeProfiles := checkDuplicates(strings.Split(strActiveProfiles, ","))
 
-	if err = loadFiles(l, confDir, activeProfiles); err != nil {
-		return nil, errutil.Stack(err, "refresh error in source local")
+	if len(activeProfiles) > 0 {
+		if err = c.loadFiles(l, confDir, activeProfiles); err != nil {
+			return nil, errutil.Stack(err, "refresh error in source local")
+		}
+	}
+
+	mask, err := conf.Resolve(l, "${spring.app.config.mask:="+DefaultMaskPattern+"}")
+	if err != nil {
+		return nil, err
 	}
+	if c.origins.mask, err = regexp.Compile(mask); err != nil {
+		return nil, errutil.Explain(err, "invalid spring.app.config.mask")
+	}
+	c.origins.storage = l
 	return l, nil
 }
 
@@ -118,7 +195,17 @@ func checkDuplicates(arr []string) []string {
 //
 // Non-existent files are skipped, while other loading errors abort the process.
 // Loaded files may declare additional imports via spring.app.imports.
-func loadFiles(l *flatten.LayeredStorage, dir string, activeProfiles []string) error {
+//
+// When profiles are active, the profile-gated documents of the yaml
+// application files are loaded first, so that profile-specific files
+// still override them.
+func (c *AppConfig) loadFiles(l *layeredStorage, dir string, activeProfiles []string) error {
+	if activeProfiles != nil {
+		if err := c.loadProfileDocuments(l, dir, activeProfiles); err != nil {
+			return err
+		}
+	}
+
 	extensions := []string{".properties", ".yaml", ".yml", ".toml", ".tml", ".json"}
 
 	var files []string
@@ -146,52 +233,114 @@ func loadFiles(l *flatten.LayeredStorage, dir string, activeProfiles []string) e
 		if err != nil {
 			// Don't use `os.IsNotExist`
 			if errors.Is(err, os.ErrNotExist) {
+				c.missing = append(c.missing, filename)
 				continue
 			}
 			return err
 		}
 
 		// Add the file to the layered storage
+		c.sources = append(c.sources, filename)
 		if activeProfiles == nil {
-			l.AddStorage(flatten.StorageAppFile, flatten.NewPropertiesStorage(p), filename)
+			c.addStorage(l, flatten.StorageAppFile, p, filename)
 		} else {
-			l.AddStorage(flatten.StorageProfileFile, flatten.NewPropertiesStorage(p), filename)
+			c.addStorage(l, flatten.StorageProfileFile, p, filename)
 		}
 
 		// Load file imports; later-loaded sources override earlier ones
-		if err = loadFileImports(l, p, activeProfiles); err != nil {
+		if err = c.loadFileImports(l, p, activeProfiles); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// loadProfileDocuments adds the documents of app.yaml and app.yml that are
+// gated by spring.config.activate.on-profile to the profile layer. A document
+// is applied when any of its profiles is active, in the order of the file.
+func (c *AppConfig) loadProfileDocuments(l *layeredStorage, dir string, activeProfiles []string) error {
+	for _, ext := range []string{".yaml", ".yml"} {
+		filename, err := conf.Resolve(l, filepath.Join(dir, "app"+ext))
+		if err != nil {
+			return err
+		}
+
+		b, err := os.ReadFile(filename)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return errutil.Explain(err, "read file %s error", filename)
+		}
+
+		docs, err := yaml.ReadDocuments(b)
+		if err != nil {
+			return errutil.Explain(err, "read file %s error", filename)
+		}
+		c.sources = append(c.sources, filename)
+
+		for _, doc := range docs {
+			if !slices.ContainsFunc(yaml.Profiles(doc), func(s string) bool {
+				return slices.Contains(activeProfiles, s)
+			}) {
+				continue
+			}
+			p := flatten.MapProperties(doc)
+			c.addStorage(l, flatten.StorageProfileFile, p, filename)
+			if err = c.loadFileImports(l, p, activeProfiles); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // loadFileImports loads additional configuration files declared by
 // the property `spring.app.imports`.
 //
 // Only one level of import is supported; imported files are not allowed
 // to declare further imports.
-func loadFileImports(l *flatten.LayeredStorage, p *flatten.Properties, activeProfiles []string) error {
-	var i struct {
-		Imports []string `value:"${spring.app.imports:=}"`
-	}
-	if err := conf.Bind(flatten.NewPropertiesStorage(p), &i); err != nil {
-		return err
-	}
-	for _, source := range checkDuplicates(i.Imports) {
+func (c *AppConfig) loadFileImports(l *layeredStorage, p *flatten.Properties, activeProfiles []string) error {
+	for _, source := range checkDuplicates(fileImports(p)) {
 		str, err := conf.Resolve(l, source)
 		if err != nil {
 			return err
 		}
-		c, err := conf.Load(str)
+		m, err := conf.Load(str)
 		if err != nil {
 			return err
 		}
+		// the name leaves out the credentials of http headers
+		name := provider.SourceName(str)
+		c.sources = append(c.sources, name)
 		if activeProfiles == nil {
-			l.AddStorage(flatten.StorageAppFile, flatten.NewPropertiesStorage(c), str)
+			c.addStorage(l, flatten.StorageAppFile, m, name)
 		} else {
-			l.AddStorage(flatten.StorageProfileFile, flatten.NewPropertiesStorage(c), str)
+			c.addStorage(l, flatten.StorageProfileFile, m, name)
 		}
 	}
 	return nil
 }
+
+// fileImports returns the sources declared by the property
+// `spring.app.imports` of a file, either a comma separated list or a
+// list of values. The placeholders of the sources are left unresolved,
+// as they may refer to the properties of any layer.
+func fileImports(p *flatten.Properties) []string {
+	const key = "spring.app.imports"
+	if s, ok := p.Get(key); ok {
+		return strings.Split(s, ",")
+	}
+	m := make(map[string]string)
+	if !flatten.NewPropertiesStorage(p).SliceEntries(key, m) {
+		return nil
+	}
+	var ret []string
+	for i := 0; ; i++ {
+		s, ok := m[key+"["+strconv.Itoa(i)+"]"]
+		if !ok {
+			return ret
+		}
+		ret = append(ret, s)
+	}
+}
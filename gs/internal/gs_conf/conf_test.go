@@ -17,7 +17,10 @@
 package gs_conf
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/go-spring/spring-core/conf"
@@ -96,6 +99,46 @@ func TestAppConfig(t *testing.T) {
 		assert.That(t, config.SpringAppName).Equal("cmd-override-app")
 	})
 
+	t.Run("relaxed keys follow layers", func(t *testing.T) {
+		t.Cleanup(clean)
+		tmpDir := t.TempDir()
+		err := os.WriteFile(tmpDir+"/app.properties", []byte("server.port=8080\nlog.level=info\ndb.max-conns=5"), 0644)
+		assert.That(t, err).Nil()
+		err = os.WriteFile(tmpDir+"/app-dev.properties", []byte("db.max_conns=9"), 0644)
+		assert.That(t, err).Nil()
+		os.Args = []string{"test", "-D", "server.port=1"}
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_DIR", tmpDir)
+		_ = os.Setenv("GS_SPRING_PROFILES_ACTIVE", "dev")
+		_ = os.Setenv("SERVER_PORT", "9999")
+		_ = os.Setenv("LOG_LEVEL", "debug")
+		p, err := NewAppConfig().Refresh()
+		assert.That(t, err).Nil()
+
+		var config struct {
+			ServerPort int    `value:"${server.port}"`
+			LogLevel   string `value:"${log.level}"`
+			MaxConns   int    `value:"${db.maxConns}"`
+		}
+		err = conf.Bind(p, &config)
+		assert.That(t, err).Nil()
+		assert.That(t, config.ServerPort).Equal(1)
+		assert.That(t, config.LogLevel).Equal("debug")
+		assert.That(t, config.MaxConns).Equal(9)
+
+		s, err := conf.Resolve(p, "${server.port}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("1")
+
+		var strict struct {
+			ServerPort int `value:"${server.port}"`
+		}
+		r := NewKeyRecorder(p)
+		err = conf.Bind(r, &strict)
+		assert.That(t, err).Nil()
+		assert.That(t, strict.ServerPort).Equal(1)
+		assert.That(t, r.Consumed("server.port")).True()
+	})
+
 	t.Run("sys conf override by config file", func(t *testing.T) {
 		t.Cleanup(clean)
 		c := NewAppConfig()
@@ -169,6 +212,51 @@ func TestAppConfig(t *testing.T) {
 		assert.That(t, config.LogLevel).Equal("debug")
 	})
 
+	t.Run("profile documents in yaml file", func(t *testing.T) {
+		t.Cleanup(clean)
+		tmpDir := t.TempDir()
+		appYaml := tmpDir + "/app.yaml"
+		err := os.WriteFile(appYaml, []byte(strings.Join([]string{
+			"spring.app.name: default",
+			"server.port: 8080",
+			"db.host: localhost",
+			"---",
+			"spring.config.activate.on-profile: dev",
+			"server.port: 9090",
+			"db.host: dev-db",
+			"---",
+			"spring.config.activate.on-profile: prod",
+			"server.port: 80",
+		}, "\n")), 0644)
+		assert.That(t, err).Nil()
+		devProps := tmpDir + "/app-dev.properties"
+		err = os.WriteFile(devProps, []byte("db.host=dev-db.example.com"), 0644)
+		assert.That(t, err).Nil()
+
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_DIR", tmpDir)
+		_ = os.Setenv("GS_SPRING_PROFILES_ACTIVE", "dev")
+		c := NewAppConfig()
+		p, err := c.Refresh()
+		assert.That(t, err).Nil()
+
+		var config struct {
+			SpringAppName string `value:"${spring.app.name}"`
+			ServerPort    string `value:"${server.port}"`
+			DbHost        string `value:"${db.host}"`
+		}
+		err = conf.Bind(p, &config)
+		assert.That(t, err).Nil()
+		assert.That(t, config.SpringAppName).Equal("default")
+		assert.That(t, config.ServerPort).Equal("9090")
+		assert.That(t, config.DbHost).Equal("dev-db.example.com")
+
+		// the base document is overridden by the profile document
+		o, ok := c.PropertySources().Origin("server.port")
+		assert.That(t, ok).True()
+		assert.That(t, o.SourceValue).Equal(SourceValue{Value: "9090", Source: appYaml})
+		assert.That(t, o.Overridden).Equal([]SourceValue{{Value: "8080", Source: appYaml}})
+	})
+
 	t.Run("import config file", func(t *testing.T) {
 		t.Cleanup(clean)
 		tmpDir := t.TempDir()
@@ -197,6 +285,217 @@ func TestAppConfig(t *testing.T) {
 		assert.That(t, config.DbUser).Equal("admin")
 	})
 
+	t.Run("loaded sources", func(t *testing.T) {
+		t.Cleanup(clean)
+		tmpDir := t.TempDir()
+		importedProps := tmpDir + "/imported.properties"
+		err := os.WriteFile(importedProps, []byte("db.host=imported-host"), 0644)
+		assert.That(t, err).Nil()
+		appYaml := tmpDir + "/app.yaml"
+		err = os.WriteFile(appYaml, []byte("spring.app.imports: file:"+importedProps), 0644)
+		assert.That(t, err).Nil()
+		devProps := tmpDir + "/app-dev.properties"
+		err = os.WriteFile(devProps, []byte("db.host=dev-host"), 0644)
+		assert.That(t, err).Nil()
+
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_DIR", tmpDir)
+		_ = os.Setenv("GS_SPRING_PROFILES_ACTIVE", "dev")
+		c := NewAppConfig()
+		_, err = c.Refresh()
+		assert.That(t, err).Nil()
+		assert.That(t, c.Sources()).Equal([]string{
+			appYaml,
+			"file:" + importedProps,
+			devProps,
+		})
+		assert.That(t, c.WatchSources()).Equal([]string{
+			appYaml,
+			"file:" + importedProps,
+			devProps,
+			tmpDir + "/app.properties",
+			tmpDir + "/app.yml",
+			tmpDir + "/app.toml",
+			tmpDir + "/app.tml",
+			tmpDir + "/app.json",
+			tmpDir + "/app-dev.yaml",
+			tmpDir + "/app-dev.yml",
+			tmpDir + "/app-dev.toml",
+			tmpDir + "/app-dev.tml",
+			tmpDir + "/app-dev.json",
+		})
+	})
+
+	t.Run("http import credentials", func(t *testing.T) {
+		t.Cleanup(clean)
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer s3cret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte("db.host=remote-host"))
+		}))
+		defer svr.Close()
+
+		tmpDir := t.TempDir()
+		appProps := tmpDir + "/app.properties"
+		source := svr.URL + "/app.properties#header.Authorization=Bearer%20${CONFIG_TOKEN}"
+		err := os.WriteFile(appProps, []byte("spring.app.imports="+source), 0644)
+		assert.That(t, err).Nil()
+
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_DIR", tmpDir)
+		_ = os.Setenv("CONFIG_TOKEN", "s3cret")
+		c := NewAppConfig()
+		p, err := c.Refresh()
+		assert.That(t, err).Nil()
+
+		s, err := conf.Resolve(p, "${db.host}")
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal("remote-host")
+
+		name := svr.URL + "/app.properties"
+		assert.That(t, c.Sources()).Equal([]string{appProps, name})
+		o, ok := c.PropertySources().Origin("db.host")
+		assert.That(t, ok).True()
+		assert.That(t, o.Source).Equal(name)
+	})
+
+	t.Run("resolved and masked origins", func(t *testing.T) {
+		t.Cleanup(clean)
+		tmpDir := t.TempDir()
+		err := os.Mkdir(tmpDir+"/secrets", 0755)
+		assert.That(t, err).Nil()
+		secretProps := tmpDir + "/secrets/db.properties"
+		err = os.WriteFile(secretProps, []byte("db.user=root"), 0644)
+		assert.That(t, err).Nil()
+		appProps := tmpDir + "/app.properties"
+		err = os.WriteFile(appProps, []byte(strings.Join([]string{
+			"spring.app.imports=" + secretProps,
+			"db.host=localhost",
+			"db.url=mysql://${db.host}:${db.port:=3306}",
+			"db.password=pw",
+			"db.dsn=${db.user}:${db.password}@${db.host}",
+			"db.key=ENC(abc)",
+			"db.alias=${db.key}",
+		}, "\n")), 0644)
+		assert.That(t, err).Nil()
+
+		os.Args = []string{"test", "-D", "db.url=mysql://${db.host}"}
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_DIR", tmpDir)
+		c := NewAppConfig()
+		_, err = c.Refresh()
+		assert.That(t, err).Nil()
+
+		s := c.PropertySources()
+		o, _ := s.Origin("db.url")
+		assert.That(t, o.SourceValue).Equal(SourceValue{Value: "mysql://localhost", Source: "cmd"})
+		assert.That(t, o.Overridden).Equal([]SourceValue{{Value: "mysql://localhost:3306", Source: appProps}})
+
+		for _, key := range []string{"db.user", "db.dsn", "db.key", "db.alias"} {
+			o, _ = s.Origin(key)
+			assert.That(t, o.Value).Equal(MaskedValue)
+		}
+	})
+
+	t.Run("property origins", func(t *testing.T) {
+		t.Cleanup(clean)
+		tmpDir := t.TempDir()
+		appProps := tmpDir + "/app.properties"
+		err := os.WriteFile(appProps, []byte("server.port=8080\ndb.password=app\ndb.host=localhost"), 0644)
+		assert.That(t, err).Nil()
+		devProps := tmpDir + "/app-dev.properties"
+		err = os.WriteFile(devProps, []byte("server.port=9090\ndb.password=dev"), 0644)
+		assert.That(t, err).Nil()
+
+		os.Args = []string{"test", "-D", "server.port=7070"}
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_DIR", tmpDir)
+		_ = os.Setenv("GS_SPRING_PROFILES_ACTIVE", "dev")
+		c := NewAppConfig()
+		c.Properties.Set("server.port", "80")
+		_, err = c.Refresh()
+		assert.That(t, err).Nil()
+
+		s := c.PropertySources()
+		o, ok := s.Origin("server.port")
+		assert.That(t, ok).True()
+		assert.That(t, o).Equal(PropertyOrigin{
+			Key:         "server.port",
+			SourceValue: SourceValue{Value: "7070", Source: "cmd"},
+			Overridden: []SourceValue{
+				{Value: "9090", Source: devProps},
+				{Value: "8080", Source: appProps},
+				{Value: "80", Source: "default"},
+			},
+		})
+
+		o, ok = s.Origin("db.password")
+		assert.That(t, ok).True()
+		assert.That(t, o).Equal(PropertyOrigin{
+			Key:         "db.password",
+			SourceValue: SourceValue{Value: MaskedValue, Source: devProps},
+			Overridden: []SourceValue{
+				{Value: MaskedValue, Source: appProps},
+			},
+		})
+
+		_, ok = s.Origin("db")
+		assert.That(t, ok).False()
+
+		var keys []string
+		for _, o = range s.Origins() {
+			keys = append(keys, o.Key)
+		}
+		assert.That(t, keys).Equal([]string{
+			"db.host",
+			"db.password",
+			"server.port",
+			"spring.app.config.dir",
+			"spring.profiles.active",
+		})
+	})
+
+	t.Run("unused keys", func(t *testing.T) {
+		t.Cleanup(clean)
+		tmpDir := t.TempDir()
+		appProps := tmpDir + "/app.properties"
+		err := os.WriteFile(appProps, []byte(
+			"server.port=8080\nserver.adress=localhost\n"+
+				"hosts[0]=a\nhosts[1]=b\ncustom.x=1\nspring.config.strict=true"), 0644)
+		assert.That(t, err).Nil()
+		devProps := tmpDir + "/app-dev.properties"
+		err = os.WriteFile(devProps, []byte("db.host=dev"), 0644)
+		assert.That(t, err).Nil()
+
+		os.Args = []string{"test", "-D", "cmd.only=1"}
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_DIR", tmpDir)
+		_ = os.Setenv("GS_SPRING_PROFILES_ACTIVE", "dev")
+		c := NewAppConfig()
+		c.Properties.Set("default.only", "1")
+		p, err := c.Refresh()
+		assert.That(t, err).Nil()
+
+		r := NewKeyRecorder(p)
+		var s struct {
+			Port  int      `value:"${server.port}"`
+			Hosts []string `value:"${hosts}"`
+		}
+		err = conf.Bind(r, &s)
+		assert.That(t, err).Nil()
+		assert.That(t, r.Consumed("server.port")).True()
+		assert.That(t, r.Consumed("hosts[1]")).True()
+
+		keys := c.PropertySources().UnusedKeys(r, nil)
+		assert.That(t, keys).Equal([]string{"custom.x", "db.host", "server.adress"})
+		keys = c.PropertySources().UnusedKeys(r, []string{"custom", "db.host", "server.ad"})
+		assert.That(t, keys).Equal([]string{"server.adress"})
+	})
+
+	t.Run("invalid mask pattern", func(t *testing.T) {
+		t.Cleanup(clean)
+		_ = os.Setenv("GS_SPRING_APP_CONFIG_MASK", "(")
+		_, err := NewAppConfig().Refresh()
+		assert.Error(t, err).Matches("invalid spring.app.config.mask")
+	})
+
 	t.Run("import file not exist", func(t *testing.T) {
 		t.Cleanup(clean)
 		tmpDir := t.TempDir()
@@ -0,0 +1,146 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/stdlib/flatten"
+)
+
+// MaskedValue replaces the values of keys matching the mask pattern.
+const MaskedValue = "******"
+
+// DefaultMaskPattern matches the keys whose values are masked by default.
+// It can be changed by the property spring.app.config.mask.
+const DefaultMaskPattern = `(?i)(password|passwd|secret|token|credential|private[-_.]?key)`
+
+// SourceValue is the value of a property in one configuration source.
+type SourceValue struct {
+	Value  string // effective value, masked if it is sensitive
+	Source string // source name, e.g. "cmd", "env" or a file name
+}
+
+// PropertyOrigin describes where the effective value of a property comes
+// from, and which values of lower-priority sources it overrides.
+type PropertyOrigin struct {
+	Key string
+	SourceValue
+	Overridden []SourceValue // from higher to lower priority
+}
+
+// namedSource is a configuration source added to a layer.
+type namedSource struct {
+	props *flatten.Properties
+	name  string
+}
+
+// PropertySources records the sources of a flatten.LayeredStorage,
+// in the same order of precedence, so that the origin of every
+// property can be reported.
+type PropertySources struct {
+	layers  [flatten.StorageMax][]namedSource
+	mask    *regexp.Regexp
+	storage flatten.Storage // resolves the references of the values
+}
+
+// refPattern matches the keys referenced by a value, e.g. "db.password"
+// in "${db.password}" or "${db.password:=none}".
+var refPattern = regexp.MustCompile(`\$\{([^:{}]+)`)
+
+// add records a source; like flatten.LayeredStorage.AddStorage,
+// sources added later take precedence within the same layer.
+func (s *PropertySources) add(layer int, p *flatten.Properties, name string) {
+	if name == "" {
+		name = "default"
+	}
+	s.layers[layer] = append([]namedSource{{props: p, name: name}}, s.layers[layer]...)
+}
+
+// Origin returns the origin of a leaf property, or false if it is not set.
+// The values are resolved, and masked if they are sensitive, see value.
+func (s *PropertySources) Origin(key string) (PropertyOrigin, bool) {
+	var values []SourceValue
+	for _, arr := range s.layers {
+		for _, source := range arr {
+			if v, ok := source.props.Get(key); ok {
+				v = s.value(key, v, source.name)
+				values = append(values, SourceValue{Value: v, Source: source.name})
+			}
+		}
+	}
+	if len(values) == 0 {
+		return PropertyOrigin{}, false
+	}
+	return PropertyOrigin{
+		Key:         key,
+		SourceValue: values[0],
+		Overridden:  values[1:],
+	}, true
+}
+
+// Origins returns the origins of all leaf properties, sorted by key.
+func (s *PropertySources) Origins() []PropertyOrigin {
+	keys := make(map[string]struct{})
+	for _, arr := range s.layers {
+		for _, source := range arr {
+			for k := range source.props.Data() {
+				keys[k] = struct{}{}
+			}
+		}
+	}
+	var ret []PropertyOrigin
+	for _, k := range slices.Sorted(maps.Keys(keys)) {
+		o, _ := s.Origin(k)
+		ret = append(ret, o)
+	}
+	return ret
+}
+
+// value returns the effective value of a property in a source. The value
+// is masked if the key or the source name matches the mask pattern, e.g.
+// "db.password" or "configtree:/run/secrets/", if it is encrypted, or if
+// it refers to a key that matches the pattern or holds an encrypted value.
+// The raw value is returned if it can't be resolved.
+func (s *PropertySources) value(key, val, source string) string {
+	if s.mask != nil {
+		if s.mask.MatchString(key) || s.mask.MatchString(source) || conf.IsEncrypted(val) {
+			return MaskedValue
+		}
+		for _, m := range refPattern.FindAllStringSubmatch(val, -1) {
+			if s.mask.MatchString(m[1]) {
+				return MaskedValue
+			}
+			if s.storage == nil {
+				continue
+			}
+			if v, ok := s.storage.Value(m[1]); ok && conf.IsEncrypted(v) {
+				return MaskedValue
+			}
+		}
+	}
+	if s.storage == nil {
+		return val
+	}
+	if v, err := conf.ResolveValue(s.storage, key, val); err == nil {
+		return v
+	}
+	return val
+}
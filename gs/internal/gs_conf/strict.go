@@ -0,0 +1,153 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_conf
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/stdlib/flatten"
+)
+
+// Values of the property spring.config.strict.
+const (
+	StrictOff   = "false" // unused keys are ignored
+	StrictWarn  = "warn"  // unused keys are logged as warnings
+	StrictError = "error" // unused keys fail the startup, same as "true"
+)
+
+// strictExemptPrefixes are consumed by the configuration system itself,
+// before the properties are recorded.
+var strictExemptPrefixes = []string{
+	"spring.config",
+	"spring.profiles",
+	"spring.app.config",
+	"spring.app.imports",
+}
+
+// KeyRecorder is a flatten.Storage that records the keys looked up
+// through it, so that the keys nothing consumed can be reported.
+type KeyRecorder struct {
+	flatten.Storage
+	owner  *KeyRecorder // records the keys of a layer, if not nil
+	layers []conf.Layer
+	mu     sync.Mutex
+	keys   map[string]struct{}
+}
+
+// NewKeyRecorder returns a KeyRecorder wrapping p.
+func NewKeyRecorder(p flatten.Storage) *KeyRecorder {
+	r := &KeyRecorder{
+		Storage: p,
+		keys:    make(map[string]struct{}),
+	}
+	if l, ok := p.(conf.LayeredStorage); ok {
+		for _, layer := range l.Layers() {
+			layer.Storage = &KeyRecorder{Storage: layer.Storage, owner: r}
+			r.layers = append(r.layers, layer)
+		}
+	} else {
+		r.layers = []conf.Layer{{Storage: r, Env: true}}
+	}
+	return r
+}
+
+// Layers returns the layers of the wrapped storage, which record the
+// keys looked up through them as well, see conf.LayeredStorage.
+func (r *KeyRecorder) Layers() []conf.Layer {
+	return r.layers
+}
+
+// record marks the keys as consumed.
+func (r *KeyRecorder) record(keys ...string) {
+	if r.owner != nil {
+		r.owner.record(keys...)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, k := range keys {
+		r.keys[k] = struct{}{}
+	}
+}
+
+// Exists records the key and checks whether it exists.
+func (r *KeyRecorder) Exists(key string) bool {
+	r.record(key)
+	return r.Storage.Exists(key)
+}
+
+// Value records the key and returns its value.
+func (r *KeyRecorder) Value(key string) (string, bool) {
+	r.record(key)
+	return r.Storage.Value(key)
+}
+
+// SliceEntries records the entries of a slice node and returns them,
+// as they are usually bound from a copy rather than looked up again.
+func (r *KeyRecorder) SliceEntries(key string, result map[string]string) bool {
+	m := make(map[string]string)
+	if !r.Storage.SliceEntries(key, m) {
+		return false
+	}
+	r.record(slices.Collect(maps.Keys(m))...)
+	maps.Copy(result, m)
+	return true
+}
+
+// Consumed reports whether the key has been looked up.
+func (r *KeyRecorder) Consumed(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.keys[key]
+	return ok
+}
+
+// UnusedKeys returns, sorted, the keys of the app and profile files that
+// were not consumed through r, except for the keys under the allowed
+// prefixes, e.g. "custom" allows "custom.a" and "custom[0]".
+func (s *PropertySources) UnusedKeys(r *KeyRecorder, allow []string) []string {
+	allow = append(slices.Clone(strictExemptPrefixes), allow...)
+	keys := make(map[string]struct{})
+	for _, layer := range []int{flatten.StorageProfileFile, flatten.StorageAppFile} {
+		for _, source := range s.layers[layer] {
+			for k := range source.props.Data() {
+				if !r.Consumed(k) && !hasPrefix(k, allow) {
+					keys[k] = struct{}{}
+				}
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(keys))
+}
+
+// hasPrefix reports whether the key is under one of the prefixes.
+func hasPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if prefix = strings.TrimSpace(prefix); prefix == "" {
+			continue
+		}
+		str, ok := strings.CutPrefix(key, prefix)
+		if ok && (str == "" || str[0] == '.' || str[0] == '[') {
+			return true
+		}
+	}
+	return false
+}
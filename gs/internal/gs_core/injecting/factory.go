@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package injecting
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/go-spring/spring-core/gs/internal/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_bean"
+	"github.com/go-spring/stdlib/errutil"
+)
+
+var beanFactoryType = reflect.TypeFor[gs.BeanFactory]()
+
+// beanFactory implements gs.BeanFactory. The indexes of the beans are
+// only retained after refresh if a beanFactory is injected, so the
+// lookups cost no memory to the applications that don't use them.
+// The indexes are read-only after refresh, and the beans are wired
+// by wireLater, so the lookups are safe for concurrent use.
+type beanFactory struct {
+	c *Injector
+}
+
+// Get returns the bean of the type, with the name if given, wiring it
+// if needed. The new instances of prototype beans belong to the caller,
+// their destroy callbacks aren't called by the container.
+func (f *beanFactory) Get(t reflect.Type, name ...string) (any, error) {
+	var tag WireTag
+	if len(name) > 0 {
+		tag.beanName = name[0]
+	}
+	b, err := f.c.selectBean(t, tag)
+	if err != nil {
+		return nil, err
+	}
+	var ret *gs_bean.BeanDefinition
+	_, err = f.c.wireLater(func(stack *Stack) (err error) {
+		ret, err = f.c.instanceOf(b, stack)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret.Interface(), nil
+}
+
+// GetAll returns all the beans of the type, in the order of injection
+// into a slice, wiring them if needed.
+func (f *beanFactory) GetAll(t reflect.Type) ([]any, error) {
+	var beans []*gs_bean.BeanDefinition
+	_, err := f.c.wireLater(func(stack *Stack) (err error) {
+		beans, err = f.c.getBeans(reflect.SliceOf(t), nil, true, stack)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, 0, len(beans))
+	for _, b := range beans {
+		values = append(values, b.Interface())
+	}
+	return values, nil
+}
+
+// Names returns the names of all the beans, sorted.
+func (f *beanFactory) Names() []string {
+	return slices.Sorted(maps.Keys(f.c.beansByName))
+}
+
+// Definition returns the definition of the bean that matches the ID,
+// by type and name, or by name only if the type is nil.
+func (f *beanFactory) Definition(id gs.BeanID) (gs.BeanInfo, error) {
+	var beans []*gs_bean.BeanDefinition
+	if id.Type != nil {
+		beans = f.c.findBeans(id)
+	} else {
+		beans = f.c.beansByName[id.Name]
+	}
+	switch len(beans) {
+	case 0:
+		return nil, errutil.Explain(nil, "can't find bean %s", id)
+	case 1:
+		return beans[0], nil
+	default:
+		return nil, errutil.Explain(nil, "found %d beans %s", len(beans), id)
+	}
+}
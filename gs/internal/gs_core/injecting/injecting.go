@@ -26,6 +26,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/go-spring/log"
 	"github.com/go-spring/spring-core/conf"
@@ -56,6 +57,7 @@ type Injecting struct {
 	p           *gs_dync.Properties                        // Dynamic properties provider
 	beansByName map[string][]*gs_bean.BeanDefinition       // Beans indexed by name
 	beansByType map[reflect.Type][]*gs_bean.BeanDefinition // Beans indexed by type
+	mu          sync.Mutex                                 // Guards destroyers added after refresh
 	destroyers  []func()                                   // Cleanup functions in reverse order
 }
 
@@ -93,6 +95,9 @@ func (c *Injecting) RefreshProperties(p flatten.Storage) error {
 // Behavior is influenced by properties:
 // - spring.allow-circular-references: whether lazy circular references are allowed.
 // - spring.force-autowire-is-nullable: whether missing dependencies are treated as nullable.
+// - spring.aggregate-bind-errors: whether the property binding errors of all the
+// beans wired during refresh are reported together, instead of the first one only.
+// Once a binding error occurs, the beans are still bound but not initialized.
 func (c *Injecting) Refresh(roots, beans []*gs_bean.BeanDefinition) (err error) {
 	var forceAutowireIsNullable bool
 	{
@@ -100,6 +105,12 @@ func (c *Injecting) Refresh(roots, beans []*gs_bean.BeanDefinition) (err error)
 		forceAutowireIsNullable, _ = strconv.ParseBool(s)
 	}
 
+	var aggregateBindErrors bool
+	{
+		s, _ := c.p.Data().Value("spring.aggregate-bind-errors")
+		aggregateBindErrors, _ = strconv.ParseBool(s)
+	}
+
 	// Index beans by name and type for lookup
 	c.beansByName = make(map[string][]*gs_bean.BeanDefinition)
 	c.beansByType = make(map[reflect.Type][]*gs_bean.BeanDefinition)
@@ -115,7 +126,11 @@ func (c *Injecting) Refresh(roots, beans []*gs_bean.BeanDefinition) (err error)
 	defer func() {
 		// If an error occurred, or there are unresolved beans in the stack,
 		// enrich the error message with the dependency path for easier debugging.
-		if err != nil || len(stack.beans) > 0 {
+		// The aggregated binding errors are returned as they are, as
+		// they belong to no single bean.
+		if _, ok := err.(*conf.BindErrors); ok && len(stack.beans) == 0 {
+			log.Errorf(context.Background(), log.TagAppDef, "%s", err)
+		} else if err != nil || len(stack.beans) > 0 {
 			err = errutil.Explain(nil, "%s ↩\n%s", err, stack.Path())
 			log.Errorf(context.Background(), log.TagAppDef, "%s", err)
 		}
@@ -127,16 +142,32 @@ func (c *Injecting) Refresh(roots, beans []*gs_bean.BeanDefinition) (err error)
 		beansByName:             c.beansByName,
 		beansByType:             c.beansByType,
 		forceAutowireIsNullable: forceAutowireIsNullable,
+		aggregateBindErrors:     aggregateBindErrors,
+		owner:                   c,
+		contexts:                newContextScope(),
 	}
 
 	// Step 1: Wire all root beans.
+	// Prototype and context scoped beans are created on demand.
 	r.state = Refreshing
+	if aggregateBindErrors {
+		r.bindErrors = &conf.BindErrors{}
+	}
 	for _, b := range roots {
+		if b.GetScope() != gs.ScopeSingleton {
+			continue
+		}
 		if err = r.wireBean(b, stack); err != nil {
 			return err
 		}
 	}
+	if r.bindErrors.Len() > 0 {
+		return r.bindErrors
+	}
+	r.bindErrors = nil
+	r.mu.Lock()
 	r.state = Refreshed
+	r.mu.Unlock()
 
 	// Step 2: Handle lazy fields caused by circular dependencies.
 	for _, f := range stack.lazyFields {
@@ -146,8 +177,11 @@ func (c *Injecting) Refresh(roots, beans []*gs_bean.BeanDefinition) (err error)
 		}
 	}
 
-	// Step 3: Collect destroyer callbacks in dependency-safe order.
-	c.destroyers = stack.getSortedDestroyers()
+	// Step 3: Collect destroyer callbacks in dependency-safe order, after
+	// those of the beans already wired through providers since Step 1.
+	c.mu.Lock()
+	c.destroyers = append(c.destroyers, stack.getSortedDestroyers(nil)...)
+	c.mu.Unlock()
 
 	// Step 4: Clean up metadata.
 	if c.p.ObjectsCount() == 0 {
@@ -163,11 +197,27 @@ func (c *Injecting) Refresh(roots, beans []*gs_bean.BeanDefinition) (err error)
 // ensuring that beans are destroyed after the beans they depend on.
 // Any errors returned from destroy methods are logged but do not stop the shutdown process.
 func (c *Injecting) Close() {
-	for _, f := range c.destroyers {
+	c.mu.Lock()
+	destroyers := c.destroyers
+	c.destroyers = nil
+	c.mu.Unlock()
+	for _, f := range destroyers {
 		f()
 	}
 }
 
+// addDestroyers adds the destroyers of the beans wired after refresh.
+// They run before the others, as they may depend on the beans wired
+// during refresh but not the other way around.
+func (c *Injecting) addDestroyers(destroyers []func()) {
+	if len(destroyers) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.destroyers = append(destroyers, c.destroyers...)
+}
+
 // Injector performs core dependency injection and bean lifecycle management.
 // Responsibilities include:
 // - Constructor invocation and creation of bean values.
@@ -176,12 +226,19 @@ func (c *Injecting) Close() {
 // - Bean status management (creating, created, wired).
 // - Lazy field handling and circular dependency detection.
 // - Respecting forceAutowireIsNullable flag to treat missing dependencies as optional.
+// - Respecting aggregateBindErrors flag to report all binding errors at once.
+// - Creating instances of prototype and context scoped beans.
 type Injector struct {
+	mu                      sync.Mutex                                 // Serializes wiring after refresh
 	state                   refreshState                               // Current wiring state
 	p                       *gs_dync.Properties                        // Property resolver
 	beansByName             map[string][]*gs_bean.BeanDefinition       // Beans indexed by name
 	beansByType             map[reflect.Type][]*gs_bean.BeanDefinition // Beans indexed by type
 	forceAutowireIsNullable bool                                       // Treat missing references as nullable
+	aggregateBindErrors     bool                                       // Report all binding errors together
+	bindErrors              *conf.BindErrors                           // Binding errors of the beans wired during refresh
+	owner                   *Injecting                                 // Container destroying the singletons
+	contexts                *contextScope                              // Instances of context scoped beans
 }
 
 // findBeans retrieves all beans matching the specified BeanID.
@@ -195,7 +252,7 @@ func (c *Injector) findBeans(beanID gs.BeanID) []*gs_bean.BeanDefinition {
 	if beanID.Name != "" {
 		var ret []*gs_bean.BeanDefinition
 		for _, b := range beans {
-			if beanID.Name == b.GetName() {
+			if b.MatchName(beanID.Name) {
 				ret = append(ret, b)
 			}
 		}
@@ -205,9 +262,10 @@ func (c *Injector) findBeans(beanID gs.BeanID) []*gs_bean.BeanDefinition {
 }
 
 // WireTag represents the parsed structure of an injection tag.
-// Format: "BeanName?" where "?" marks the dependency as nullable.
+// Format: "BeanName?" where "?" marks the dependency as nullable, and
+// "@Qualifier?" selects the beans by qualifier instead of name.
 type WireTag struct {
-	beanName string // The target bean's name
+	beanName string // The target bean's name, or '@' and qualifier
 	nullable bool   // Whether the injection can be nil
 }
 
@@ -248,12 +306,26 @@ func toWireString(tags []WireTag) string {
 
 // getBean retrieves a single bean of the given type that matches the WireTag.
 // Behavior:
-// - Validates the type is suitable for injection.
-// - Filters by bean name if WireTag.beanName is set.
-// - Respects WireTag.nullable; returns nil if no matching bean and nullable.
-// - Returns an error if multiple matching beans are found.
+// - Selects the bean as selectBean does.
+// - Creates a new instance for a prototype bean, see instanceOf.
 // - If the container is currently Refreshing, the bean will be wired before returning.
 func (c *Injector) getBean(t reflect.Type, tag WireTag, stack *Stack) (*gs_bean.BeanDefinition, error) {
+	b, err := c.selectBean(t, tag)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return c.instanceOf(b, stack)
+}
+
+// selectBean selects the definition of a single bean of the given type
+// that matches the WireTag, without wiring it.
+// Behavior:
+// - Validates the type is suitable for injection.
+// - Filters by bean name, or qualifier like "@readonly", if WireTag.beanName is set.
+// - Respects WireTag.nullable; returns nil if no matching bean and nullable.
+// - Selects the primary bean if multiple matching beans are found.
+// - Returns an error if multiple matching beans are found and none or several are primary.
+func (c *Injector) selectBean(t reflect.Type, tag WireTag) (*gs_bean.BeanDefinition, error) {
 	// Ensure the target type is valid for injection.
 	if !typeutil.IsBeanInjectionTarget(t) {
 		return nil, errutil.Explain(nil, "%s is not a valid receiver type", t.String())
@@ -261,7 +333,7 @@ func (c *Injector) getBean(t reflect.Type, tag WireTag, stack *Stack) (*gs_bean.
 
 	var foundBeans []*gs_bean.BeanDefinition
 	for _, b := range c.beansByType[t] {
-		if tag.beanName == "" || tag.beanName == b.GetName() {
+		if tag.beanName == "" || b.MatchName(tag.beanName) {
 			foundBeans = append(foundBeans, b)
 		}
 	}
@@ -274,6 +346,15 @@ func (c *Injector) getBean(t reflect.Type, tag WireTag, stack *Stack) (*gs_bean.
 	}
 
 	if len(foundBeans) > 1 {
+		var primary []*gs_bean.BeanDefinition
+		for _, b := range foundBeans {
+			if b.IsPrimary() {
+				primary = append(primary, b)
+			}
+		}
+		if len(primary) == 1 {
+			return primary[0], nil
+		}
 		msg := fmt.Sprintf("found %d beans, bean:%q type:%q [", len(foundBeans), tag, t)
 		for _, b := range foundBeans {
 			msg += "( " + b.String() + " ), "
@@ -281,25 +362,128 @@ func (c *Injector) getBean(t reflect.Type, tag WireTag, stack *Stack) (*gs_bean.
 		msg = msg[:len(msg)-2] + "]"
 		return nil, errutil.Explain(nil, "%s", msg)
 	}
+	return foundBeans[0], nil
+}
 
-	b := foundBeans[0]
-	if c.state == Refreshing {
-		if err := c.wireBean(b, stack); err != nil {
+// instanceOf returns the bean to inject for a definition: a new instance
+// of a prototype bean, or the singleton, which is wired first if the
+// container is currently Refreshing or wiring later. Context scoped beans
+// can only be injected through a ScopedProvider.
+func (c *Injector) instanceOf(b *gs_bean.BeanDefinition, stack *Stack) (*gs_bean.BeanDefinition, error) {
+	switch b.GetScope() {
+	case gs.ScopePrototype:
+		return c.newInstance(b, stack)
+	case gs.ScopeContext:
+		return nil, errutil.Explain(nil, "context scoped bean %s should be injected through ScopedProvider", b)
+	default:
+		if c.state == Refreshing || stack.later {
+			if err := c.wireBean(b, stack); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+}
+
+// newInstance creates and wires a new instance of a prototype or context
+// scoped bean. A bean depending on a new instance of itself is circular.
+func (c *Injector) newInstance(b *gs_bean.BeanDefinition, stack *Stack) (*gs_bean.BeanDefinition, error) {
+	beanID := b.BeanID()
+	if slices.ContainsFunc(stack.beans, func(x *gs_bean.BeanDefinition) bool {
+		return x.BeanID() == beanID
+	}) {
+		return nil, errutil.Explain(nil, "found circular autowire")
+	}
+	i := b.NewInstance()
+	if err := c.wireBean(i, stack); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// wireLater wires beans with fn after refresh, e.g. for the providers.
+// Singletons wired by fn, i.e. not needed by the beans wired during
+// refresh, are destroyed with the container, while the destroyers of the
+// other beans, e.g. new instances of prototype beans, are returned.
+// Wiring is serialized, so fn must not wait for another wireLater.
+func (c *Injector) wireLater(fn func(stack *Stack) error) (_ []func(), err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state != Refreshed {
+		return nil, errutil.Explain(nil, "beans can't be wired before refresh")
+	}
+
+	stack := NewStack()
+	stack.later = true
+	defer func() {
+		if err != nil {
+			err = errutil.Explain(nil, "%s ↩\n%s", err, stack.Path())
+		}
+	}()
+
+	if err = fn(stack); err != nil {
+		return nil, err
+	}
+
+	// Handle lazy fields of the beans wired by fn.
+	for _, f := range stack.lazyFields {
+		tag := strings.TrimSuffix(f.tag, ",lazy")
+		if err = c.autowire(f.v, tag, stack); err != nil {
 			return nil, err
 		}
 	}
-	return b, nil
+
+	c.owner.addDestroyers(stack.getSortedDestroyers(func(b *gs_bean.BeanDefinition) bool {
+		return b.GetScope() == gs.ScopeSingleton
+	}))
+	return stack.getSortedDestroyers(func(b *gs_bean.BeanDefinition) bool {
+		return b.GetScope() != gs.ScopeSingleton
+	}), nil
 }
 
 // getBeans retrieves a collection (slice or map) of beans matching the element type.
+// The beans are selected as selectBeans does, and each of them is resolved by
+// instanceOf, so prototype beans get new instances, and during Refreshing
+// state, singletons are wired. The beans of each group are then sorted by
+// order and name, which the Ordered beans only know once created.
+func (c *Injector) getBeans(t reflect.Type, tags []WireTag, nullable bool,
+	stack *Stack) ([]*gs_bean.BeanDefinition, error) {
+
+	groups, err := c.selectBeans(t, tags, nullable)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the instances, wiring them if the container is refreshing
+	var ret []*gs_bean.BeanDefinition
+	for _, group := range groups {
+		arr := make([]*gs_bean.BeanDefinition, 0, len(group))
+		for _, b := range group {
+			i, err := c.instanceOf(b, stack)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, i)
+		}
+		sortBeans(arr)
+		ret = append(ret, arr...)
+	}
+	return ret, nil
+}
+
+// selectBeans selects the definitions of a collection (slice or map) of beans
+// matching the element type, without wiring them. The beans are returned in
+// groups, whose order is given by the tags, while the beans of a group are
+// to be sorted by sortBeans.
 // Supports optional WireTags for ordering and selection.
+// - Tags with a name select one bean, which is a group of its own.
+// - Tags like "@readonly" select all the beans with the qualifier.
 // - Tags with '*' act as a wildcard for remaining unordered beans.
 // - Tags marked nullable are skipped if not found.
-// - Ensures deterministic order: before '*' -> '*' -> after '*'.
+// - Without tags, all the beans are in one group.
 // - Returns an error if required beans are missing or duplicates are detected.
-// - During Refreshing state, each returned bean is wired before inclusion.
-func (c *Injector) getBeans(t reflect.Type, tags []WireTag, nullable bool,
-	stack *Stack) ([]*gs_bean.BeanDefinition, error) {
+func (c *Injector) selectBeans(t reflect.Type, tags []WireTag, nullable bool) ([][]*gs_bean.BeanDefinition, error) {
 
 	et := t.Elem()
 	if !typeutil.IsBeanInjectionTarget(et) {
@@ -307,111 +491,141 @@ func (c *Injector) getBeans(t reflect.Type, tags []WireTag, nullable bool,
 	}
 
 	beans := c.beansByType[et]
+	if len(tags) == 0 {
+		if len(beans) == 0 {
+			return c.noBeansCollected(tags, nullable)
+		}
+		return [][]*gs_bean.BeanDefinition{beans}, nil
+	}
 
 	// Process bean tags to filter and order beans
-	if len(tags) > 0 {
-		var (
-			anyBeans  []int // indices of beans to be placed in the '*' section
-			afterAny  []int // beans to appear after the '*'
-			beforeAny []int // beans to appear before the '*'
-		)
-		foundAny := false
-		for _, item := range tags {
-
-			// If we see the "*" wildcard, record its presence
-			if item.beanName == "*" {
-				if foundAny {
-					return nil, errutil.Explain(nil, "more than one * in collection %q", tags)
-				}
-				foundAny = true
-				continue
-			}
-
-			// Find beans with the specified name
-			var founds []int
-			for i, b := range beans {
-				if item.beanName == b.GetName() {
-					founds = append(founds, i)
-				}
+	var (
+		groups   [][]*gs_bean.BeanDefinition
+		selected []int // indices of the beans selected by the tags
+		anyIndex = -1  // position of the '*' group in groups
+	)
+	for _, item := range tags {
+
+		// If we see the "*" wildcard, record its position
+		if item.beanName == "*" {
+			if anyIndex >= 0 {
+				return nil, errutil.Explain(nil, "more than one * in collection %q", tags)
 			}
+			anyIndex = len(groups)
+			continue
+		}
 
-			// Error if there are multiple beans with the same name
-			if len(founds) > 1 {
-				msg := fmt.Sprintf("found %d beans, bean:%q type:%q [", len(founds), item, t)
-				for _, i := range founds {
-					msg += "( " + beans[i].String() + " ), "
-				}
-				msg = msg[:len(msg)-2] + "]"
-				return nil, errutil.Explain(nil, "%s", msg)
+		// Find beans with the specified name, or qualifier
+		var founds []int
+		for i, b := range beans {
+			if b.MatchName(item.beanName) {
+				founds = append(founds, i)
 			}
+		}
 
-			// Error if no matching bean is found (unless the tag is nullable)
-			if len(founds) == 0 {
-				if item.nullable {
-					continue
-				}
-				return nil, errutil.Explain(nil, "can't find bean, bean:%q type:%q", item, t)
+		// Error if there are multiple beans with the same name,
+		// while a qualifier selects all the beans that have it
+		if len(founds) > 1 && !strings.HasPrefix(item.beanName, "@") {
+			msg := fmt.Sprintf("found %d beans, bean:%q type:%q [", len(founds), item, t)
+			for _, i := range founds {
+				msg += "( " + beans[i].String() + " ), "
 			}
+			msg = msg[:len(msg)-2] + "]"
+			return nil, errutil.Explain(nil, "%s", msg)
+		}
 
-			// Classify beans as before or after the '*'
-			if foundAny {
-				afterAny = append(afterAny, founds[0])
-			} else {
-				beforeAny = append(beforeAny, founds[0])
+		// Error if no matching bean is found (unless the tag is nullable)
+		if len(founds) == 0 {
+			if item.nullable {
+				continue
 			}
+			return nil, errutil.Explain(nil, "can't find bean, bean:%q type:%q", item, t)
 		}
 
-		// For the '*' wildcard, include all other beans that were not explicitly listed
-		if foundAny {
-			temp := append(beforeAny, afterAny...)
-			for i := range len(beans) {
-				if slices.Contains(temp, i) {
-					continue
-				}
-				anyBeans = append(anyBeans, i)
+		// Group the beans that aren't selected by the previous tags
+		var group []*gs_bean.BeanDefinition
+		for _, i := range founds {
+			if slices.Contains(selected, i) {
+				continue
 			}
+			selected = append(selected, i)
+			group = append(group, beans[i])
 		}
-
-		// Assemble beans in the correct order: beforeAny -> anyBeans -> afterAny
-		n := len(beforeAny) + len(anyBeans) + len(afterAny)
-		arr := make([]*gs_bean.BeanDefinition, 0, n)
-		for _, i := range beforeAny {
-			arr = append(arr, beans[i])
+		if len(group) > 0 {
+			groups = append(groups, group)
 		}
-		for _, i := range anyBeans {
-			arr = append(arr, beans[i])
+	}
+
+	// For the '*' wildcard, include all other beans that were not explicitly listed
+	if anyIndex >= 0 {
+		var group []*gs_bean.BeanDefinition
+		for i, b := range beans {
+			if !slices.Contains(selected, i) {
+				group = append(group, b)
+			}
 		}
-		for _, i := range afterAny {
-			arr = append(arr, beans[i])
+		if len(group) > 0 {
+			groups = slices.Insert(groups, anyIndex, group)
 		}
-
-		beans = arr
 	}
 
 	// Handle the case where no beans were found
-	if len(beans) == 0 {
-		if nullable {
-			return nil, nil
-		}
-		return nil, errutil.Explain(nil, "no beans collected for %q", toWireString(tags))
+	if len(groups) == 0 {
+		return c.noBeansCollected(tags, nullable)
 	}
+	return groups, nil
+}
 
-	// If the container is in the refreshing state, wire the beans before returning them
-	if c.state == Refreshing {
-		for _, b := range beans {
-			if err := c.wireBean(b, stack); err != nil {
-				return nil, err
+// noBeansCollected returns the result of selectBeans when no bean is found.
+func (c *Injector) noBeansCollected(tags []WireTag, nullable bool) ([][]*gs_bean.BeanDefinition, error) {
+	if nullable {
+		return nil, nil
+	}
+	return nil, errutil.Explain(nil, "no beans collected for %q", toWireString(tags))
+}
+
+// parseCollectionTag parses the tag of a collection into its WireTags,
+// and reports whether the collection is nullable. An empty tag collects
+// all the beans, and "?" all the beans, if any.
+func (c *Injector) parseCollectionTag(str string) (tags []WireTag, nullable bool) {
+	if str != "" {
+		nullable = true
+		if str != "?" {
+			for s := range strings.SplitSeq(str, ",") {
+				g := parseWireTag(s)
+				tags = append(tags, g)
+				if !g.nullable {
+					nullable = false
+				}
 			}
 		}
 	}
-	return beans, nil
+
+	// If forced nullable mode is enabled, override all tags
+	if c.forceAutowireIsNullable {
+		for i := range len(tags) {
+			tags[i].nullable = true
+		}
+		nullable = true
+	}
+	return tags, nullable
+}
+
+// sortBeans sorts beans by order, and then by name for deterministic order.
+func sortBeans(beans []*gs_bean.BeanDefinition) {
+	sort.SliceStable(beans, func(i, j int) bool {
+		if oi, oj := beans[i].GetOrder(), beans[j].GetOrder(); oi != oj {
+			return oi < oj
+		}
+		return beans[i].GetName() < beans[j].GetName()
+	})
 }
 
 // autowire injects dependencies into the given reflect.Value according to the tag string.
 // - Supports single beans, slices, and maps.
 // - Resolves placeholders (e.g., ${...}) from configuration.
 // - Honors lazy or nullable tags, including forceAutowireIsNullable setting.
-// - Populates slices/maps with wired bean values, sorting slices by bean name.
+// - Populates slices/maps with wired bean values, in the order of getBeans.
 func (c *Injector) autowire(v reflect.Value, str string, stack *Stack) error {
 	// Resolve placeholder expressions (e.g., ${...}) from configuration
 	str, err := conf.Resolve(c.p.Data(), str)
@@ -419,34 +633,27 @@ func (c *Injector) autowire(v reflect.Value, str string, stack *Stack) error {
 		return err
 	}
 
+	// The bean factory retains the beans for the lookups after refresh
+	if v.Type() == beanFactoryType {
+		v.Set(reflect.ValueOf(&beanFactory{c: c}))
+		return nil
+	}
+
+	// Providers are injected with handles that get the beans later
+	if v.CanAddr() {
+		switch p := v.Addr().Interface().(type) {
+		case scopedProvider:
+			return c.wireScopedProvider(p, str)
+		case lazyProvider:
+			return c.wireProvider(p, str)
+		}
+	}
+
 	switch v.Kind() {
 	case reflect.Map, reflect.Slice, reflect.Array:
 		{
 			// Handle collection types
-			var nullable bool
-			var tags []WireTag
-
-			// Parse the tag string to determine nullability and tag list
-			if str != "" {
-				nullable = true
-				if str != "?" {
-					for s := range strings.SplitSeq(str, ",") {
-						g := parseWireTag(s)
-						tags = append(tags, g)
-						if !g.nullable {
-							nullable = false
-						}
-					}
-				}
-			}
-
-			// If forced nullable mode is enabled, override all tags
-			if c.forceAutowireIsNullable {
-				for i := range len(tags) {
-					tags[i].nullable = true
-				}
-				nullable = true
-			}
+			tags, nullable := c.parseCollectionTag(str)
 
 			// Retrieve the beans matching the tag and type
 			beans, err := c.getBeans(v.Type(), tags, nullable, stack)
@@ -457,10 +664,6 @@ func (c *Injector) autowire(v reflect.Value, str string, stack *Stack) error {
 			// Populate the collection field with the resolved beans
 			switch v.Kind() {
 			case reflect.Slice:
-				// Sort beans by name for deterministic order
-				sort.Slice(beans, func(i, j int) bool {
-					return beans[i].GetName() < beans[j].GetName()
-				})
 				ret := reflect.MakeSlice(v.Type(), 0, 0)
 				for _, b := range beans {
 					ret = reflect.Append(ret, b.GetValue())
@@ -505,9 +708,17 @@ func (c *Injector) autowire(v reflect.Value, str string, stack *Stack) error {
 func (c *Injector) wireBean(b *gs_bean.BeanDefinition, stack *Stack) error {
 	//fmt.Println(b.String())
 
+	// Beans wired during refresh already have their destroyers registered
+	if stack.later && b.Status() == gs_bean.StatusWired {
+		return nil
+	}
+
 	// Wire all dependent beans before creating the current bean
 	for _, s := range b.GetDependsOn() {
 		for _, d := range c.findBeans(s) {
+			if d.GetScope() != gs.ScopeSingleton {
+				continue
+			}
 			if err := c.wireBean(d, stack); err != nil {
 				return err
 			}
@@ -548,8 +759,9 @@ func (c *Injector) wireBean(b *gs_bean.BeanDefinition, stack *Stack) error {
 			return err
 		}
 
-		// Invoke the bean's initialization method if defined
-		if b.GetInit() != nil {
+		// Invoke the bean's initialization method if defined, unless
+		// some properties failed to bind and are reported after refresh
+		if b.GetInit() != nil && c.bindErrors.Len() == 0 {
 			fnValue := reflect.ValueOf(b.GetInit())
 			out := fnValue.Call([]reflect.Value{b.GetValue()})
 			if len(out) > 0 && !out[0].IsNil() {
@@ -641,7 +853,26 @@ func (c *Injector) wireBeanValue(v reflect.Value, t reflect.Type, stack *Stack)
 	}
 
 	param := conf.BindParam{Path: typeName}
-	return c.wireStruct(v, t, param, stack)
+	if !c.aggregateBindErrors {
+		return c.wireStruct(v, t, param, stack)
+	}
+
+	// During refresh, the errors of all the beans are reported together
+	// once the root beans are wired.
+	if c.bindErrors != nil {
+		param.Errors = c.bindErrors
+		return c.wireStruct(v, t, param, stack)
+	}
+
+	// Bind all the properties of the bean before reporting the errors.
+	param.Errors = &conf.BindErrors{}
+	if err := c.wireStruct(v, t, param, stack); err != nil {
+		return err
+	}
+	if param.Errors.Len() > 0 {
+		return param.Errors
+	}
+	return nil
 }
 
 // wireStruct inspects each field of a struct and performs wiring as needed.
@@ -650,6 +881,7 @@ func (c *Injector) wireBeanValue(v reflect.Value, t reflect.Type, stack *Stack)
 // - Supports anonymous/embedded structs recursively.
 // - Defers lazy injection fields by appending to the stack's lazyFields.
 func (c *Injector) wireStruct(v reflect.Value, t reflect.Type, opt conf.BindParam, stack *Stack) error {
+	numErrors := opt.Errors.Len()
 	for i := range t.NumField() {
 		ft := t.Field(i)
 		fv := v.Field(i)
@@ -680,8 +912,9 @@ func (c *Injector) wireStruct(v reflect.Value, t reflect.Type, opt conf.BindPara
 		}
 
 		subParam := conf.BindParam{
-			Key:  opt.Key,
-			Path: fieldPath,
+			Key:    opt.Key,
+			Path:   fieldPath,
+			Errors: opt.Errors,
 		}
 
 		// If the field has a "value" tag, bind configuration to it
@@ -710,7 +943,12 @@ func (c *Injector) wireStruct(v reflect.Value, t reflect.Type, opt conf.BindPara
 			}
 		}
 	}
-	return nil
+
+	// cross-field rules need all fields to be bound
+	if opt.Errors.Len() > numErrors {
+		return nil
+	}
+	return conf.ValidateCrossFields(v, opt)
 }
 
 // destroyer represents a bean's cleanup (destroy) function
@@ -747,17 +985,18 @@ type destroyerList = listutil.List[*gs_bean.BeanDefinition]
 // It keeps track of the current wiring call stack, lazily injected fields,
 // and the ordering of destroyers for proper shutdown.
 type Stack struct {
-	beans        []*gs_bean.BeanDefinition // The stack of beans currently being wired
-	lazyFields   []LazyField               // Fields deferred due to lazy injection
-	destroyers   *destroyerList            // Ordered list of destroyers
-	destroyerMap map[gs.BeanID]*destroyer  // Fast lookup map for destroyers by bean ID
+	beans        []*gs_bean.BeanDefinition              // The stack of beans currently being wired
+	lazyFields   []LazyField                            // Fields deferred due to lazy injection
+	destroyers   *destroyerList                         // Ordered list of destroyers
+	destroyerMap map[*gs_bean.BeanDefinition]*destroyer // Fast lookup map for destroyers by bean
+	later        bool                                   // Whether wiring after refresh
 }
 
 // NewStack creates and initializes a new Stack for a fresh Refresh or Wire operation.
 func NewStack() *Stack {
 	return &Stack{
 		destroyers:   listutil.New[*gs_bean.BeanDefinition](),
-		destroyerMap: make(map[gs.BeanID]*destroyer),
+		destroyerMap: make(map[*gs_bean.BeanDefinition]*destroyer),
 	}
 }
 
@@ -798,25 +1037,27 @@ func (s *Stack) Path() (path string) {
 // pushDestroyer registers a destroyer for the given bean.
 // It also records dependencies so that beans are destroyed in the correct order.
 func (s *Stack) pushDestroyer(b *gs_bean.BeanDefinition) {
-	beanID := gs.BeanID{Name: b.GetName(), Type: b.GetType()}
 
-	// Get or create the destroyer entry for this bean
-	d, ok := s.destroyerMap[beanID]
+	// Get or create the destroyer entry for this bean, instances of
+	// prototype beans have their own entries
+	d, ok := s.destroyerMap[b]
 	if !ok {
 		d = &destroyer{current: b}
-		s.destroyerMap[beanID] = d
+		s.destroyerMap[b] = d
 	}
 
 	// Record dependencies
 	for _, depBeanID := range b.GetDependsOn() {
-		if depDestroyer, ok := s.destroyerMap[depBeanID]; ok {
-			d.dependOn(depDestroyer.current)
+		for dep := range s.destroyerMap {
+			if dep.BeanID() == depBeanID {
+				d.dependOn(dep)
+			}
 		}
 	}
 
 	// If there is a previously registered destroyer, current depends on it
 	if x := s.destroyers.Back(); x.Valid() {
-		s.destroyerMap[x.Value().BeanID()].dependOn(b)
+		s.destroyerMap[x.Value()].dependOn(b)
 	}
 
 	// Add the current bean to the end of the destroyer list
@@ -845,7 +1086,8 @@ func getBeforeDestroyers(destroyers *list.List, i any) *list.List {
 // getSortedDestroyers returns destroyer functions in execution order.
 // Topological sort ensures each bean is destroyed after all beans it depends on.
 // The returned slice can be safely iterated to close the container.
-func (s *Stack) getSortedDestroyers() []func() {
+// If match is not nil, only the destroyers of the matching beans are returned.
+func (s *Stack) getSortedDestroyers(match func(b *gs_bean.BeanDefinition) bool) []func() {
 
 	// Helper to wrap a bean's destroy method as a no-argument function
 	destroy := func(v reflect.Value, fn any) func() {
@@ -872,6 +1114,9 @@ func (s *Stack) getSortedDestroyers() []func() {
 	var ret []func()
 	for e := destroyers.Front(); e != nil; e = e.Next() {
 		d := e.Value.(*destroyer).current
+		if match != nil && !match(d) {
+			continue
+		}
 		ret = append(ret, destroy(d.GetValue(), d.GetDestroy()))
 	}
 	return ret
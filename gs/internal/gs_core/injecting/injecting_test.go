@@ -18,12 +18,14 @@ package injecting
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"reflect"
 	"runtime"
 	"testing"
 	"time"
 
+	"github.com/go-spring/spring-core/conf"
 	"github.com/go-spring/spring-core/gs/internal/gs"
 	"github.com/go-spring/spring-core/gs/internal/gs_arg"
 	"github.com/go-spring/spring-core/gs/internal/gs_bean"
@@ -584,6 +586,69 @@ func TestInjecting(t *testing.T) {
 		assert.Error(t, err).Matches("property \"svr.config.int\" not exist")
 	})
 
+	t.Run("wire error - aggregate bind errors", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"spring": map[string]any{
+				"aggregate-bind-errors": true,
+			},
+			"svr": map[string]any{
+				"config": map[string]any{
+					"int": "abc",
+				},
+			},
+		})))
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(new(struct {
+				ServiceConfig `value:"${svr}"`
+				Port          int `value:"${port}"`
+			})),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches(`ServiceConfig.Int type=int error: strconv.ParseInt: parsing "abc": invalid syntax; ` +
+			`.*property "svr.config.str" not exist; .*property "port" not exist`)
+	})
+
+	t.Run("wire error - aggregate bind errors of all beans", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"spring": map[string]any{
+				"aggregate-bind-errors": true,
+			},
+		})))
+		var initialized bool
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(new(struct {
+				Host string `value:"${host}"`
+			})),
+			objectBean(new(struct {
+				Port int `value:"${port}"`
+			})),
+			objectBean(&SimpleLogger{}).Init(func(*SimpleLogger) {
+				initialized = true
+			}),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches(`property "host" not exist; .*property "port" not exist$`)
+		var errs *conf.BindErrors
+		assert.That(t, errors.As(err, &errs)).True()
+		assert.That(t, errs.Len()).Equal(2)
+		assert.That(t, initialized).False()
+	})
+
+	t.Run("wire error - cross field validation", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.MapProperties(map[string]any{
+			"min-conns": 5,
+			"max-conns": 2,
+		})))
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(new(struct {
+				MinConns int `value:"${min-conns}" validate:"min=1"`
+				MaxConns int `value:"${max-conns}" expr:"$ >= MinConns"`
+			})),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches(`validate path=.*\.MaxConns type=int error: validate failed on "\$ >= MinConns" for value 2`)
+	})
+
 	t.Run("wire error - destruction failure", func(t *testing.T) {
 		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
 		beans := []*gs_bean.BeanDefinition{
@@ -942,3 +1007,564 @@ func TestDyncValue(t *testing.T) {
 		assert.That(t, r.beansByType).Nil()
 	})
 }
+
+type ScopeSession struct {
+	Counter *Counter `autowire:""`
+	ID      int
+	closed  bool
+}
+
+type ScopeHandler struct {
+	Session ScopedProvider[*ScopeSession] `autowire:""`
+}
+
+func TestScope(t *testing.T) {
+
+	t.Run("prototype", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		s := new(struct {
+			A  *DestroyA   `autowire:""`
+			B  *DestroyA   `autowire:""`
+			As []*DestroyA `autowire:""`
+		})
+		var destroyed int
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(s),
+			objectBean(&Counter{}),
+			objectBean(&DestroyA{value: 5}).Scope(gs.ScopePrototype).Destroy(func(d *DestroyA) {
+				destroyed++
+			}),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, s.A != s.B).True()
+		assert.That(t, s.As[0] != s.A && s.As[0] != s.B).True()
+		assert.That(t, s.A.Counter).Equal(s.B.Counter)
+		assert.That(t, s.A.value).Equal(5)
+		r.Close()
+		assert.That(t, destroyed).Equal(3)
+	})
+
+	t.Run("prototype - constructor", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		s := new(struct {
+			A *ChildBean `autowire:""`
+			B *ChildBean `autowire:""`
+		})
+		var n int
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(s),
+			provideBean(func() *ChildBean {
+				n++
+				return &ChildBean{Value: n}
+			}).Scope(gs.ScopePrototype),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, s.A.Value+s.B.Value).Equal(3)
+	})
+
+	t.Run("prototype - circular", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(new(struct {
+				Node *ChildBean `autowire:""`
+			})),
+			provideBean(func(c *ChildBean) *ChildBean {
+				return c
+			}).Scope(gs.ScopePrototype),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches("found circular autowire")
+	})
+
+	t.Run("context", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		h := &ScopeHandler{}
+		destroyed := make(chan *ScopeSession, 2)
+		var n int
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(h),
+			objectBean(&Counter{}),
+			objectBean(&ScopeSession{}).Scope(gs.ScopeContext).Init(func(s *ScopeSession) {
+				n++
+				s.ID = n
+			}).Destroy(func(s *ScopeSession) {
+				s.closed = true
+				destroyed <- s
+			}),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+
+		ctx1, cancel1 := context.WithCancel(context.Background())
+		ctx2, cancel2 := context.WithCancel(context.Background())
+		defer cancel2()
+
+		s1, err := h.Session.Get(ctx1)
+		assert.That(t, err).Nil()
+		assert.That(t, s1.ID).Equal(1)
+		assert.That(t, s1.Counter).NotNil()
+
+		s, err := h.Session.Get(ctx1)
+		assert.That(t, err).Nil()
+		assert.That(t, s).Equal(s1)
+
+		s2, err := h.Session.Get(ctx2)
+		assert.That(t, err).Nil()
+		assert.That(t, s2.ID).Equal(2)
+
+		cancel1()
+		assert.That(t, <-destroyed).Equal(s1)
+		assert.That(t, s1.closed).True()
+		assert.That(t, s2.closed).False()
+
+		_, err = h.Session.Get(ctx1)
+		assert.Error(t, err).Matches("context canceled")
+
+		_, err = h.Session.Get(context.Background())
+		assert.Error(t, err).Matches("context of bean .* isn't cancelable")
+	})
+
+	t.Run("context - wired on demand", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		h := &ScopeHandler{}
+		var destroyed bool
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(h),
+			objectBean(&Counter{}).Destroy(func(c *Counter) {
+				destroyed = true
+			}),
+			objectBean(&ScopeSession{}).Scope(gs.ScopeContext),
+		}
+		err := r.Refresh(beans[:1], beans)
+		assert.That(t, err).Nil()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		s, err := h.Session.Get(ctx)
+		assert.That(t, err).Nil()
+		assert.That(t, s.Counter).NotNil()
+
+		r.Close()
+		assert.That(t, destroyed).True()
+	})
+
+	t.Run("context - injected directly", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(new(struct {
+				Session *ScopeSession `autowire:""`
+			})),
+			objectBean(&ScopeSession{}).Scope(gs.ScopeContext),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches("context scoped bean .* should be injected through ScopedProvider")
+	})
+
+	t.Run("context - not context scoped", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(&ScopeHandler{}),
+			objectBean(&ScopeSession{}),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches("bean .* isn't context scoped")
+	})
+}
+
+type ProviderA struct {
+	B Provider[*ProviderB] `autowire:""`
+}
+
+type ProviderB struct {
+	A *ProviderA `autowire:""`
+}
+
+type LazyProvider struct {
+	Child Provider[*ChildBean] `autowire:""`
+}
+
+func TestProvider(t *testing.T) {
+
+	t.Run("wired on first get", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		s := new(struct {
+			Child Provider[*ChildBean] `autowire:""`
+		})
+		var created, destroyed int
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(s),
+			provideBean(func() *ChildBean {
+				created++
+				return &ChildBean{Value: 3}
+			}).Destroy(func(*ChildBean) {
+				destroyed++
+			}),
+		}
+		err := r.Refresh(beans[:1], beans)
+		assert.That(t, err).Nil()
+		assert.That(t, created).Equal(0)
+
+		c1, err := s.Child.Get()
+		assert.That(t, err).Nil()
+		assert.That(t, c1.Value).Equal(3)
+		c2, err := s.Child.Get()
+		assert.That(t, err).Nil()
+		assert.That(t, c2).Equal(c1)
+		assert.That(t, created).Equal(1)
+
+		r.Close()
+		assert.That(t, destroyed).Equal(1)
+	})
+
+	t.Run("wired while refreshing lazy fields", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		s := new(struct {
+			L *LazyProvider `autowire:"l,lazy"`
+		})
+		var destroyed int
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(s),
+			provideBean(func() *LazyProvider {
+				return &LazyProvider{}
+			}).Name("l").Scope(gs.ScopePrototype).Init(func(l *LazyProvider) error {
+				_, err := l.Child.Get()
+				return err
+			}),
+			provideBean(func() *ChildBean { return &ChildBean{} }).Destroy(func(*ChildBean) {
+				destroyed++
+			}),
+		}
+		err := r.Refresh(beans[:1], beans)
+		assert.That(t, err).Nil()
+		r.Close()
+		assert.That(t, destroyed).Equal(1)
+	})
+
+	t.Run("circular", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		a := &ProviderA{}
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(a),
+			objectBean(&ProviderB{}),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		b, err := a.B.Get()
+		assert.That(t, err).Nil()
+		assert.That(t, b.A).Equal(a)
+	})
+
+	t.Run("collection", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		s := new(struct {
+			Filters Provider[[]Filter]          `autowire:"f2,*"`
+			Loggers Provider[map[string]Logger] `autowire:"?"`
+			Missing Provider[*Repository]       `autowire:"?"`
+		})
+		f1 := &FilterImpl{}
+		f2 := &FilterImpl{}
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(s),
+			objectBean(f1).Name("f1").Export(gs.As[Filter]()),
+			objectBean(f2).Name("f2").Export(gs.As[Filter]()),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+
+		filters, err := s.Filters.Get()
+		assert.That(t, err).Nil()
+		assert.That(t, filters).Equal([]Filter{f2, f1})
+
+		loggers, err := s.Loggers.Get()
+		assert.That(t, err).Nil()
+		assert.That(t, len(loggers)).Equal(0)
+
+		repo, err := s.Missing.Get()
+		assert.That(t, err).Nil()
+		assert.That(t, repo).Nil()
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		s := new(struct {
+			Child Provider[*ChildBean] `autowire:""`
+		})
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(s),
+			provideBean(func() *ChildBean { return &ChildBean{} }).Scope(gs.ScopePrototype),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+
+		ret := make(chan *ChildBean, 10)
+		for range 10 {
+			go func() {
+				c, _ := s.Child.Get()
+				ret <- c
+			}()
+		}
+		c := <-ret
+		assert.That(t, c).NotNil()
+		for range 9 {
+			assert.That(t, <-ret).Equal(c)
+		}
+	})
+
+	t.Run("bean not found", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(new(struct {
+				Child Provider[*ChildBean] `autowire:"child"`
+			})),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches(`can't find bean, bean:"child"`)
+	})
+
+	t.Run("not injected", func(t *testing.T) {
+		var p Provider[*ChildBean]
+		_, err := p.Get()
+		assert.Error(t, err).Matches(`no bean injected for \*injecting.ChildBean`)
+	})
+}
+
+func TestBeanFactory(t *testing.T) {
+
+	t.Run("lookup", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		s := new(struct {
+			Factory gs.BeanFactory `autowire:""`
+		})
+		l1 := &ZeroLogger{File: "a.log"}
+		l2 := &ZeroLogger{File: "b.log"}
+		var created int
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(s).Name("root"),
+			objectBean(l1).Name("a").Export(gs.As[Logger]()),
+			objectBean(l2).Name("b").Export(gs.As[Logger]()),
+			objectBean(&Counter{}).Name("counter"),
+			provideBean(func() *ChildBean {
+				created++
+				return &ChildBean{Value: created}
+			}).Name("child").Scope(gs.ScopePrototype),
+		}
+		err := r.Refresh(beans[:1], beans)
+		assert.That(t, err).Nil()
+		f := s.Factory
+
+		v, err := f.Get(reflect.TypeFor[*ZeroLogger](), "b")
+		assert.That(t, err).Nil()
+		assert.That(t, v).Equal(l2)
+
+		_, err = f.Get(reflect.TypeFor[Logger]())
+		assert.Error(t, err).Matches(`found 2 beans, bean:"" type:"injecting.Logger"`)
+
+		_, err = f.Get(reflect.TypeFor[Logger](), "c")
+		assert.Error(t, err).Matches(`can't find bean, bean:"c" type:"injecting.Logger"`)
+
+		all, err := f.GetAll(reflect.TypeFor[Logger]())
+		assert.That(t, err).Nil()
+		assert.That(t, all).Equal([]any{l1, l2})
+
+		c1, err := f.Get(reflect.TypeFor[*ChildBean]())
+		assert.That(t, err).Nil()
+		c2, err := f.Get(reflect.TypeFor[*ChildBean]())
+		assert.That(t, err).Nil()
+		assert.That(t, c1.(*ChildBean).Value).Equal(1)
+		assert.That(t, c2.(*ChildBean).Value).Equal(2)
+
+		assert.That(t, f.Names()).Equal([]string{"a", "b", "child", "counter", "root"})
+
+		d, err := f.Definition(gs.BeanID{Name: "child"})
+		assert.That(t, err).Nil()
+		assert.That(t, d.GetType()).Equal(reflect.TypeFor[*ChildBean]())
+		assert.That(t, d.GetScope()).Equal(gs.ScopePrototype)
+
+		d, err = f.Definition(gs.BeanIDFor[Logger]("a"))
+		assert.That(t, err).Nil()
+		assert.That(t, d.Exports()).Equal([]reflect.Type{gs.As[Logger]()})
+
+		_, err = f.Definition(gs.BeanIDFor[Logger]())
+		assert.Error(t, err).Matches(`found 2 beans {Type:injecting.Logger}`)
+
+		_, err = f.Definition(gs.BeanID{Name: "d"})
+		assert.Error(t, err).Matches(`can't find bean {Name:d}`)
+	})
+
+	t.Run("wired on demand", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		s := new(struct {
+			Factory gs.BeanFactory `autowire:""`
+		})
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(s),
+			objectBean(&Counter{}),
+			objectBean(&DestroyA{}),
+		}
+		err := r.Refresh(beans[:1], beans)
+		assert.That(t, err).Nil()
+
+		v, err := s.Factory.Get(reflect.TypeFor[*DestroyA]())
+		assert.That(t, err).Nil()
+		assert.That(t, v.(*DestroyA).Counter).NotNil()
+	})
+}
+
+func TestPrimaryAndQualifier(t *testing.T) {
+
+	t.Run("primary", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		s := new(struct {
+			Logger  Logger   `autowire:""`
+			Other   Logger   `autowire:"b"`
+			Loggers []Logger `autowire:""`
+		})
+		l1 := &ZeroLogger{File: "a.log"}
+		l2 := &ZeroLogger{File: "b.log"}
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(s),
+			objectBean(l1).Name("a").Export(gs.As[Logger]()).Primary(),
+			objectBean(l2).Name("b").Export(gs.As[Logger]()),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, s.Logger).Equal(Logger(l1))
+		assert.That(t, s.Other).Equal(Logger(l2))
+		assert.That(t, len(s.Loggers)).Equal(2)
+	})
+
+	t.Run("several primary", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(new(struct {
+				Logger Logger `autowire:""`
+			})),
+			objectBean(&ZeroLogger{}).Name("a").Export(gs.As[Logger]()).Primary(),
+			objectBean(&ZeroLogger{}).Name("b").Export(gs.As[Logger]()).Primary(),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches(`found 2 beans, bean:"" type:"injecting.Logger"`)
+	})
+
+	t.Run("qualifier", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		s := new(struct {
+			Writer  Logger   `autowire:"@primary"`
+			Reader  Logger   `autowire:"@readonly"`
+			Missing Logger   `autowire:"@archive?"`
+			Readers []Logger `autowire:"@readonly"`
+		})
+		l1 := &ZeroLogger{File: "a.log"}
+		l2 := &ZeroLogger{File: "b.log"}
+		l3 := &ZeroLogger{File: "c.log"}
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(s),
+			objectBean(l1).Name("a").Export(gs.As[Logger]()).Qualifier("primary"),
+			objectBean(l2).Name("b").Export(gs.As[Logger]()).Qualifier("readonly").Primary(),
+			objectBean(l3).Name("c").Export(gs.As[Logger]()).Qualifier("readonly"),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.That(t, err).Nil()
+		assert.That(t, s.Writer).Equal(Logger(l1))
+		assert.That(t, s.Reader).Equal(Logger(l2))
+		assert.That(t, s.Missing).Nil()
+		assert.That(t, s.Readers).Equal([]Logger{l2, l3})
+	})
+	t.Run("qualifier not found", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		beans := []*gs_bean.BeanDefinition{
+			objectBean(new(struct {
+				Loggers []Logger `autowire:"@archive"`
+			})),
+			objectBean(&ZeroLogger{}).Name("a").Export(gs.As[Logger]()).Qualifier("readonly"),
+		}
+		err := r.Refresh(extractBeans(beans))
+		assert.Error(t, err).Matches(`can't find bean, bean:"@archive"`)
+	})
+}
+
+type OrderedLogger struct {
+	File  string
+	order int
+}
+
+func (l *OrderedLogger) Print(msg string) {}
+
+func (l *OrderedLogger) Order() int {
+	return l.order
+}
+
+func TestOrder(t *testing.T) {
+
+	newBeans := func(s any) []*gs_bean.BeanDefinition {
+		return []*gs_bean.BeanDefinition{
+			objectBean(s),
+			objectBean(&ZeroLogger{File: "a.log"}).Name("a").Export(gs.As[Logger]()),
+			objectBean(&ZeroLogger{File: "b.log"}).Name("b").Export(gs.As[Logger]()).Qualifier("q"),
+			objectBean(&ZeroLogger{File: "c.log"}).Name("c").Export(gs.As[Logger]()).Order(-1),
+			provideBean(func() *OrderedLogger {
+				return &OrderedLogger{File: "d.log", order: -2}
+			}).Name("d").Export(gs.As[Logger]()).Qualifier("q"),
+			objectBean(&OrderedLogger{File: "e.log", order: -3}).Name("e").Export(gs.As[Logger]()).Order(1),
+		}
+	}
+
+	files := func(loggers []Logger) []string {
+		var ret []string
+		for _, l := range loggers {
+			switch x := l.(type) {
+			case *ZeroLogger:
+				ret = append(ret, x.File)
+			case *OrderedLogger:
+				ret = append(ret, x.File)
+			}
+		}
+		return ret
+	}
+
+	t.Run("by order and name", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		s := new(struct {
+			All       []Logger           `autowire:""`
+			Qualified []Logger           `autowire:"@q"`
+			Map       map[string]Logger  `autowire:""`
+			Factory   gs.BeanFactory     `autowire:""`
+			Provider  Provider[[]Logger] `autowire:""`
+		})
+		err := r.Refresh(extractBeans(newBeans(s)))
+		assert.That(t, err).Nil()
+		assert.That(t, files(s.All)).Equal([]string{"d.log", "c.log", "a.log", "b.log", "e.log"})
+		assert.That(t, files(s.Qualified)).Equal([]string{"d.log", "b.log"})
+		assert.That(t, len(s.Map)).Equal(5)
+
+		values, err := s.Factory.GetAll(reflect.TypeFor[Logger]())
+		assert.That(t, err).Nil()
+		var all []Logger
+		for _, v := range values {
+			all = append(all, v.(Logger))
+		}
+		assert.That(t, files(all)).Equal(files(s.All))
+
+		all, err = s.Provider.Get()
+		assert.That(t, err).Nil()
+		assert.That(t, files(all)).Equal(files(s.All))
+	})
+
+	t.Run("tag takes precedence", func(t *testing.T) {
+		r := New(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		s := new(struct {
+			First  []Logger `autowire:"e,*"`
+			Last   []Logger `autowire:"*,d"`
+			Listed []Logger `autowire:"b,a,e"`
+			Mixed  []Logger `autowire:"a,@q,*,c"`
+		})
+		err := r.Refresh(extractBeans(newBeans(s)))
+		assert.That(t, err).Nil()
+		assert.That(t, files(s.First)).Equal([]string{"e.log", "d.log", "c.log", "a.log", "b.log"})
+		assert.That(t, files(s.Last)).Equal([]string{"c.log", "a.log", "b.log", "e.log", "d.log"})
+		assert.That(t, files(s.Listed)).Equal([]string{"b.log", "a.log", "e.log"})
+		assert.That(t, files(s.Mixed)).Equal([]string{"a.log", "d.log", "b.log", "e.log", "c.log"})
+	})
+}
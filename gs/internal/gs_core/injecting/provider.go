@@ -0,0 +1,142 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package injecting
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/go-spring/spring-core/gs/internal/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_bean"
+	"github.com/go-spring/stdlib/errutil"
+)
+
+// Provider is injected with a handle that gets the bean on the first call
+// of Get, instead of the bean itself, e.g.
+//
+//	type Service struct {
+//	    Repo    Provider[*Repository] `autowire:""`
+//	    Filters Provider[[]Filter]    `autowire:"auth,*"`
+//	}
+//
+// The tag selects the beans as it does for a field of type T. The beans
+// are checked to exist during refresh, but wired on the first call of Get,
+// so a Provider breaks circular dependencies and delays the creation of
+// beans that are rarely used.
+type Provider[T any] struct {
+	r *resolver
+}
+
+// Get returns the bean, wiring it on the first call. The same bean, or
+// error, is returned by the later calls, even for a prototype bean.
+// Get is safe for concurrent use, but must not be called before refresh,
+// e.g. by a constructor, or by a bean that it creates.
+func (p Provider[T]) Get() (T, error) {
+	var r T
+	if p.r == nil {
+		return r, errutil.Explain(nil, "no bean injected for %s", reflect.TypeFor[T]())
+	}
+	v, err := p.r.get()
+	if err != nil {
+		return r, err
+	}
+	r, _ = v.Interface().(T) // nil for a missing nullable bean
+	return r, nil
+}
+
+// beanType returns the type of the bean.
+func (p *Provider[T]) beanType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// setResolver sets the function that wires the bean.
+func (p *Provider[T]) setResolver(fn func() (reflect.Value, error)) {
+	p.r = &resolver{fn: fn}
+}
+
+// lazyProvider is implemented by the pointers to Provider.
+type lazyProvider interface {
+	beanType() reflect.Type
+	setResolver(fn func() (reflect.Value, error))
+}
+
+// resolver calls fn once, and remembers its result.
+type resolver struct {
+	once sync.Once
+	fn   func() (reflect.Value, error)
+	v    reflect.Value
+	err  error
+}
+
+// get returns the result of fn, calling it on the first call.
+func (r *resolver) get() (reflect.Value, error) {
+	r.once.Do(func() {
+		r.v, r.err = r.fn()
+		r.fn = nil
+	})
+	return r.v, r.err
+}
+
+// wireProvider checks the beans that match the tag, and injects the
+// Provider with a resolver that wires them after refresh.
+func (c *Injector) wireProvider(p lazyProvider, str string) error {
+	t := p.beanType()
+
+	var beans []*gs_bean.BeanDefinition
+	switch t.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		tags, nullable := c.parseCollectionTag(str)
+		groups, err := c.selectBeans(t, tags, nullable)
+		if err != nil {
+			return err
+		}
+		beans = slices.Concat(groups...)
+	default:
+		g := parseWireTag(str)
+		if c.forceAutowireIsNullable {
+			g.nullable = true
+		}
+		b, err := c.selectBean(t, g)
+		if err != nil {
+			return err
+		}
+		if b != nil {
+			beans = append(beans, b)
+		}
+	}
+
+	for _, b := range beans {
+		if b.GetScope() == gs.ScopeContext {
+			return errutil.Explain(nil, "context scoped bean %s should be injected through ScopedProvider", b)
+		}
+	}
+
+	p.setResolver(func() (reflect.Value, error) {
+		v := reflect.New(t).Elem()
+		destroyers, err := c.wireLater(func(stack *Stack) error {
+			return c.autowire(v, str, stack)
+		})
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		// new instances of prototype beans live as long as the container
+		c.owner.addDestroyers(destroyers)
+		return v, nil
+	})
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package injecting
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-spring/spring-core/gs/internal/gs"
+	"github.com/go-spring/spring-core/gs/internal/gs_bean"
+	"github.com/go-spring/stdlib/errutil"
+)
+
+// ScopedProvider is injected in place of a context scoped bean, e.g.
+//
+//	type Handler struct {
+//	    Session ScopedProvider[*Session] `autowire:""`
+//	}
+//
+// and gets the instance of the bean for a context.
+type ScopedProvider[T any] struct {
+	get func(ctx context.Context) (reflect.Value, error)
+}
+
+// Get returns the instance of the bean for the context, creating it on
+// first use. The instance is destroyed when the context is done, so the
+// context must be cancelable. Contexts derived from it, e.g. by
+// context.WithValue, get their own instances.
+//
+// Get must not be called by the constructor or the init method of a bean
+// that it creates.
+func (p ScopedProvider[T]) Get(ctx context.Context) (T, error) {
+	var r T
+	if p.get == nil {
+		return r, errutil.Explain(nil, "no bean injected for %s", reflect.TypeFor[T]())
+	}
+	v, err := p.get(ctx)
+	if err != nil {
+		return r, err
+	}
+	return v.Interface().(T), nil
+}
+
+// beanType returns the type of the bean.
+func (p *ScopedProvider[T]) beanType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// setGetter sets the function that gets the instance of the bean.
+func (p *ScopedProvider[T]) setGetter(fn func(ctx context.Context) (reflect.Value, error)) {
+	p.get = fn
+}
+
+// scopedProvider is implemented by the pointers to ScopedProvider.
+type scopedProvider interface {
+	beanType() reflect.Type
+	setGetter(fn func(ctx context.Context) (reflect.Value, error))
+}
+
+// wireScopedProvider injects the context scoped bean that matches the tag
+// into the ScopedProvider.
+func (c *Injector) wireScopedProvider(p scopedProvider, str string) error {
+	if strings.Contains(str, ",") {
+		return errutil.Explain(nil, "invalid tag %q for ScopedProvider", str)
+	}
+	g := parseWireTag(str)
+	if c.forceAutowireIsNullable {
+		g.nullable = true
+	}
+	b, err := c.selectBean(p.beanType(), g)
+	if err != nil || b == nil {
+		return err
+	}
+	if b.GetScope() != gs.ScopeContext {
+		return errutil.Explain(nil, "bean %s isn't context scoped", b)
+	}
+	p.setGetter(func(ctx context.Context) (reflect.Value, error) {
+		return c.getContextBean(ctx, b)
+	})
+	return nil
+}
+
+// contextKey identifies the instance of a context scoped bean.
+type contextKey struct {
+	ctx context.Context
+	b   *gs_bean.BeanDefinition
+}
+
+// contextInstance is the instance of a context scoped bean for a context,
+// with the destroyers to run when the context is done.
+type contextInstance struct {
+	once       sync.Once
+	v          reflect.Value
+	err        error
+	destroyers []func()
+}
+
+// contextScope holds the instances of the context scoped beans.
+type contextScope struct {
+	mu        sync.Mutex
+	instances map[contextKey]*contextInstance
+}
+
+// newContextScope creates an empty contextScope.
+func newContextScope() *contextScope {
+	return &contextScope{
+		instances: make(map[contextKey]*contextInstance),
+	}
+}
+
+// getContextBean returns the instance of a context scoped bean for the
+// context, creating it on first use. The instance is destroyed, and
+// forgotten, when the context is done.
+func (c *Injector) getContextBean(ctx context.Context, b *gs_bean.BeanDefinition) (reflect.Value, error) {
+	if ctx.Done() == nil {
+		return reflect.Value{}, errutil.Explain(nil, "context of bean %s isn't cancelable", b)
+	}
+	if err := ctx.Err(); err != nil {
+		return reflect.Value{}, err
+	}
+
+	s := c.contexts
+	k := contextKey{ctx: ctx, b: b}
+	s.mu.Lock()
+	i, ok := s.instances[k]
+	if !ok {
+		i = &contextInstance{}
+		s.instances[k] = i
+		context.AfterFunc(ctx, func() {
+			s.mu.Lock()
+			delete(s.instances, k)
+			s.mu.Unlock()
+			// waits for the creation in progress, or prevents it
+			i.once.Do(func() { i.err = ctx.Err() })
+			for _, f := range i.destroyers {
+				f()
+			}
+		})
+	}
+	s.mu.Unlock()
+
+	i.once.Do(func() {
+		i.destroyers, i.err = c.wireLater(func(stack *Stack) error {
+			x, err := c.newInstance(b, stack)
+			if err != nil {
+				return err
+			}
+			i.v = x.GetValue()
+			return nil
+		})
+	})
+	return i.v, i.err
+}
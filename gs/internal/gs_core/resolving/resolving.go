@@ -223,7 +223,7 @@ func (c *Resolving) scanConfiguration(bd *gs_bean.BeanDefinition) ([]*gs_bean.Be
 
 // isBeanMatched checks whether a bean matches the given type and name selector.
 func isBeanMatched(t reflect.Type, s string, b *gs_bean.BeanDefinition) bool {
-	if s != "" && s != b.GetName() {
+	if s != "" && !b.MatchName(s) {
 		return false
 	}
 	if t != nil && t != b.GetType() {
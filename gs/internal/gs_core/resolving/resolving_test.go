@@ -162,6 +162,21 @@ func TestResolving(t *testing.T) {
 		assert.That(t, len(r.Beans())).Equal(0)
 	})
 
+	t.Run("condition on qualifier", func(t *testing.T) {
+		r := New()
+		r.Provide(&ZeroLogger{}).Name("a").Qualifier("readonly")
+		r.Provide(&TestBean{Value: 1}).Condition(gs_cond.OnBean[*ZeroLogger]("@readonly"))
+		r.Provide(&ChildBean{Value: 1}).Condition(gs_cond.OnBean[*ZeroLogger]("@primary"))
+		err := r.Refresh(flatten.NewPropertiesStorage(flatten.NewProperties(nil)))
+		assert.That(t, err).Nil()
+
+		var names []string
+		for _, b := range r.Beans() {
+			names = append(names, b.GetName())
+		}
+		assert.That(t, names).Equal([]string{"a", "TestBean"})
+	})
+
 	t.Run("duplicate bean", func(t *testing.T) {
 		r := New()
 		r.Provide(&TestBean{Value: 1})
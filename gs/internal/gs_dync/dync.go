@@ -84,6 +84,21 @@ func (r *Value[T]) MarshalJSON() ([]byte, error) {
 	return json.Marshal(r.v.Load())
 }
 
+// refreshableType is the reflect.Type of the refreshable interface.
+var refreshableType = reflect.TypeFor[refreshable]()
+
+// ValueType returns T if t is a Value[T], the type its properties are bound to.
+func ValueType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() != reflect.Struct || !reflect.PointerTo(t).Implements(refreshableType) {
+		return nil, false
+	}
+	m, ok := reflect.PointerTo(t).MethodByName("Value")
+	if !ok || m.Type.NumOut() != 1 {
+		return nil, false
+	}
+	return m.Type.Out(0), true
+}
+
 // refreshObject represents an object bound to dynamic properties that can be refreshed.
 type refreshObject struct {
 	target refreshable    // The refreshable object.
@@ -191,9 +206,12 @@ func (f *filter) Do(i any, param conf.BindParam) (bool, error) {
 	if !ok || v == nil {
 		return false, nil
 	}
+	// later refreshes don't collect errors.
+	saved := param
+	saved.Errors = nil
 	f.objects = append(f.objects, &refreshObject{
 		target: v,
-		param:  param,
+		param:  saved,
 	})
 	return true, v.onRefresh(f.prop, param, true)
 }
@@ -18,6 +18,7 @@ package gs_init
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/go-spring/spring-core/gs/internal/gs"
@@ -28,6 +29,7 @@ import (
 
 var (
 	modules []Module
+	groups  []Group
 	beans   []*gs_bean.BeanDefinition
 )
 
@@ -49,6 +51,16 @@ type Module struct {
 	FileLine   string
 }
 
+// Group describes a group of beans registered from a configuration
+// property map: each entry under Key is bound to a value of type Type,
+// which is passed to a constructor returning a bean of type BeanType.
+type Group struct {
+	Key      string
+	Type     reflect.Type
+	BeanType reflect.Type
+	FileLine string
+}
+
 // Modules returns all registered modules.
 func Modules() []Module {
 	return modules
@@ -83,9 +95,26 @@ func AddModule(c gs_cond.PropertyCondition, fn ModuleFunc, file string, line int
 	})
 }
 
+// Groups returns all registered groups.
+func Groups() []Group {
+	return groups
+}
+
+// AddGroup records a group registered from a configuration property map.
+// The group's beans are registered by a module added with AddModule.
+func AddGroup(key string, t reflect.Type, beanType reflect.Type, file string, line int) {
+	groups = append(groups, Group{
+		Key:      key,
+		Type:     t,
+		BeanType: beanType,
+		FileLine: fmt.Sprintf("%s:%d", file, line),
+	})
+}
+
 // Clear resets all registered beans and modules, effectively emptying
 // the global registry.
 func Clear() {
 	beans = nil
+	groups = nil
 	modules = nil
 }
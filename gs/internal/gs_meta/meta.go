@@ -0,0 +1,360 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gs_meta generates configuration metadata for Go-Spring
+// applications: every property that the registered beans read, with
+// its Go type, default value, validation and source location.
+//
+// The metadata is written in two formats:
+//   - configuration-metadata.json, in the format of Spring's
+//     configuration metadata, for IDE completion.
+//   - configuration-schema.json, a JSON Schema of the configuration
+//     files, for config linting.
+package gs_meta
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-spring/spring-core/conf"
+	"github.com/go-spring/spring-core/gs/internal/gs_arg"
+	"github.com/go-spring/spring-core/gs/internal/gs_bean"
+	"github.com/go-spring/spring-core/gs/internal/gs_dync"
+	"github.com/go-spring/spring-core/gs/internal/gs_init"
+	"github.com/go-spring/stdlib/errutil"
+)
+
+const (
+	// MetadataFile is the name of the file holding the metadata in the
+	// format of Spring's configuration metadata.
+	MetadataFile = "configuration-metadata.json"
+
+	// SchemaFile is the name of the file holding the JSON Schema of the
+	// configuration files.
+	SchemaFile = "configuration-schema.json"
+)
+
+// Group describes a map of properties from which a group of beans
+// is registered, see gs.Group.
+type Group struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	SourceType     string `json:"sourceType,omitempty"`
+	SourceLocation string `json:"sourceLocation,omitempty"`
+}
+
+// Property describes a property read by a bean. In the name, "*" stands
+// for the keys of a map and "[*]" for the indexes of a slice.
+type Property struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	DefaultValue   any    `json:"defaultValue,omitempty"`
+	Expr           string `json:"expr,omitempty"`
+	Validate       string `json:"validate,omitempty"`
+	SourceType     string `json:"sourceType,omitempty"`
+	SourceLocation string `json:"sourceLocation,omitempty"`
+
+	t    reflect.Type // type the value is bound to
+	text bool         // whether the value is parsed from text
+}
+
+// Metadata holds the configuration metadata of an application.
+type Metadata struct {
+	Groups     []Group    `json:"groups"`
+	Properties []Property `json:"properties"`
+}
+
+// Collect collects the metadata of the properties read by the beans,
+// through their `value` fields and TagArg constructor arguments, and by
+// the groups. Beans registered by modules aren't known until the modules
+// run, so they are not included.
+func Collect(beans []*gs_bean.BeanDefinition, groups []gs_init.Group) (*Metadata, error) {
+	m := &Metadata{
+		Groups:     []Group{},
+		Properties: []Property{},
+	}
+
+	for _, b := range beans {
+		if err := m.addBean(b.GetType(), b.Callable(), b.FileLine()); err != nil {
+			return nil, errutil.Explain(err, "collect metadata of bean %s error", b)
+		}
+	}
+
+	for _, g := range groups {
+		t := reflect.MapOf(reflect.TypeFor[string](), g.Type)
+		m.Groups = append(m.Groups, Group{
+			Name:           g.Key,
+			Type:           t.String(),
+			SourceType:     g.BeanType.String(),
+			SourceLocation: g.FileLine,
+		})
+		if err := m.add(t, "${"+g.Key+"}", g.Type.String(), g.FileLine); err != nil {
+			return nil, errutil.Explain(err, "collect metadata of group %s error", g.Key)
+		}
+		if err := m.addBean(g.BeanType, nil, g.FileLine); err != nil {
+			return nil, errutil.Explain(err, "collect metadata of group %s error", g.Key)
+		}
+	}
+
+	slices.SortStableFunc(m.Properties, func(a, b Property) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	m.Properties = slices.CompactFunc(m.Properties, func(a, b Property) bool {
+		return a.Name == b.Name && a.SourceType == b.SourceType && a.SourceLocation == b.SourceLocation
+	})
+	return m, nil
+}
+
+// addBean adds the properties of the `value` fields of a bean of type t
+// and of the TagArg arguments of its constructor f, if any.
+func (m *Metadata) addBean(t reflect.Type, f *gs_arg.Callable, fileLine string) error {
+	if t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct {
+		if err := m.add(t.Elem(), "", t.String(), fileLine); err != nil {
+			return err
+		}
+	}
+	if f == nil {
+		return nil
+	}
+	fnType := f.Type()
+	numIn := fnType.NumIn()
+	for i, arg := range f.Args() {
+		tagArg, ok := arg.(gs_arg.TagArg)
+		if !ok || !strings.HasPrefix(tagArg.Tag, "${") {
+			continue
+		}
+		var argType reflect.Type
+		if fnType.IsVariadic() && i >= numIn-1 {
+			argType = fnType.In(numIn - 1).Elem()
+		} else {
+			argType = fnType.In(i)
+		}
+		if err := m.add(argType, tagArg.Tag, t.String(), fileLine); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add adds the properties read by binding a value of type t with the tag.
+func (m *Metadata) add(t reflect.Type, tag string, sourceType string, fileLine string) error {
+	props, err := conf.DescribeProperties(t, tag, gs_dync.ValueType)
+	if err != nil {
+		return err
+	}
+	for _, p := range props {
+		if p.Key == "" {
+			continue
+		}
+		prop := Property{
+			Name:           p.Key,
+			Type:           p.Type.String(),
+			Expr:           p.Expr,
+			Validate:       p.Validate,
+			SourceType:     sourceType,
+			SourceLocation: fileLine,
+			t:              p.Type,
+			text:           p.Text,
+		}
+		if p.HasDef {
+			prop.DefaultValue = p.Default
+		}
+		m.Properties = append(m.Properties, prop)
+	}
+	return nil
+}
+
+// Schema returns a JSON Schema of configuration files holding the
+// properties. Each property has its Go type, source and validation
+// in the "x-go-type", "x-source", "x-expr" and "x-validate" keywords.
+func (m *Metadata) Schema() map[string]any {
+	root := &schemaNode{}
+	for i := range m.Properties {
+		root.add(&m.Properties[i])
+	}
+	s := root.schema()
+	s["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	return s
+}
+
+// WriteFiles writes the metadata and the JSON Schema into the directory.
+func (m *Metadata) WriteFiles(dir string) error {
+	files := map[string]any{
+		MetadataFile: m,
+		SchemaFile:   m.Schema(),
+	}
+	for name, v := range files {
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		b = append(b, '\n')
+		if err = os.WriteFile(filepath.Join(dir, name), b, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schemaNode is a node of the tree of property keys.
+type schemaNode struct {
+	prop     *Property              // property of a leaf
+	children map[string]*schemaNode // object properties
+	values   *schemaNode            // map values, for "*"
+	items    *schemaNode            // slice elements, for "[*]"
+}
+
+// add adds a property under the node.
+func (n *schemaNode) add(p *Property) {
+	for _, seg := range strings.Split(p.Name, ".") {
+		name, arrays, _ := strings.Cut(seg, "[")
+		if name == "*" {
+			if n.values == nil {
+				n.values = &schemaNode{}
+			}
+			n = n.values
+		} else {
+			if n.children == nil {
+				n.children = make(map[string]*schemaNode)
+			}
+			c, ok := n.children[name]
+			if !ok {
+				c = &schemaNode{}
+				n.children[name] = c
+			}
+			n = c
+		}
+		for range strings.Count(arrays, "*]") {
+			if n.items == nil {
+				n.items = &schemaNode{}
+			}
+			n = n.items
+		}
+	}
+	if n.prop == nil {
+		n.prop = p
+	}
+}
+
+// schema returns the JSON Schema of the node.
+func (n *schemaNode) schema() map[string]any {
+	if n.items != nil {
+		return map[string]any{"type": "array", "items": n.items.schema()}
+	}
+	if n.children == nil && n.values == nil {
+		if n.prop == nil {
+			return map[string]any{}
+		}
+		return propertySchema(n.prop)
+	}
+	s := map[string]any{"type": "object"}
+	if n.children != nil {
+		props := make(map[string]any)
+		for name, c := range n.children {
+			props[name] = c.schema()
+		}
+		s["properties"] = props
+	}
+	if n.values != nil {
+		s["additionalProperties"] = n.values.schema()
+	}
+	return s
+}
+
+// propertySchema returns the JSON Schema of a property value.
+func propertySchema(p *Property) map[string]any {
+	s := typeSchema(p.t, p.text)
+	if def, ok := p.DefaultValue.(string); ok {
+		s["default"] = defaultValue(s, def)
+	}
+	s["x-go-type"] = p.Type
+	if p.SourceLocation != "" {
+		s["x-source"] = p.SourceLocation
+	}
+	if p.Expr != "" {
+		s["x-expr"] = p.Expr
+	}
+	if p.Validate != "" {
+		s["x-validate"] = p.Validate
+	}
+	return s
+}
+
+// typeSchema returns the JSON Schema of a Go type. Values parsed from
+// text by a converter or an unmarshaler, e.g. durations, are strings.
+func typeSchema(t reflect.Type, text bool) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem(), text)}
+	case reflect.Interface:
+		return map[string]any{}
+	}
+	if text {
+		return map[string]any{"type": "string"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Map, reflect.Struct:
+		return map[string]any{"type": "object"}
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+// defaultValue converts a default value to the JSON type of the schema,
+// splitting a delimited string for an array. Defaults that don't parse,
+// e.g. references, are kept as strings.
+func defaultValue(s map[string]any, def string) any {
+	switch s["type"] {
+	case "boolean":
+		if b, err := strconv.ParseBool(def); err == nil {
+			return b
+		}
+	case "integer":
+		if i, err := strconv.ParseInt(def, 0, 64); err == nil {
+			return i
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(def, 64); err == nil {
+			return f
+		}
+	case "array":
+		arr := []any{}
+		if def = strings.TrimSpace(def); def == "" {
+			return arr
+		}
+		items, _ := s["items"].(map[string]any)
+		for v := range strings.SplitSeq(def, ",") {
+			arr = append(arr, defaultValue(items, strings.TrimSpace(v)))
+		}
+		return arr
+	}
+	return def
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gs_meta
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-spring/spring-core/gs/internal/gs_arg"
+	"github.com/go-spring/spring-core/gs/internal/gs_bean"
+	"github.com/go-spring/spring-core/gs/internal/gs_dync"
+	"github.com/go-spring/spring-core/gs/internal/gs_init"
+	"github.com/go-spring/stdlib/testing/assert"
+)
+
+type ServerConfig struct {
+	Addr    string        `value:"${addr:=:8080}" validate:"hostport"`
+	Timeout time.Duration `value:"${timeout:=5s}"`
+}
+
+type Server struct {
+	Config  ServerConfig         `value:"${http}"`
+	Debug   bool                 `value:"${debug:=false}"`
+	Limit   gs_dync.Value[int64] `value:"${limit:=100}" expr:"$ > 0"`
+	Service *Service             `autowire:""`
+}
+
+type Service struct {
+	Name string
+}
+
+func NewService(name string, ports []int) *Service {
+	return &Service{Name: name}
+}
+
+type DB struct {
+	Pool int `value:"${pool:=4}"`
+}
+
+type DBConfig struct {
+	URL   string   `value:"${url}"`
+	Hosts []string `value:"${hosts:=}"`
+}
+
+func TestCollect(t *testing.T) {
+	beans := []*gs_bean.BeanDefinition{
+		gs_bean.NewBean(new(Server)),
+		gs_bean.NewBean(NewService, gs_arg.Tag("${service.name}"), gs_arg.Tag("${service.ports:=80,443}")),
+	}
+	beans[0].SetFileLine("server.go", 10)
+	beans[1].SetFileLine("service.go", 20)
+	groups := []gs_init.Group{{
+		Key:      "db",
+		Type:     reflect.TypeFor[DBConfig](),
+		BeanType: reflect.TypeFor[*DB](),
+		FileLine: "db.go:30",
+	}}
+
+	m, err := Collect(beans, groups)
+	assert.That(t, err).Nil()
+
+	b, err := json.Marshal(m)
+	assert.That(t, err).Nil()
+	assert.String(t, string(b)).JSONEqual(`{
+		"groups": [
+			{"name": "db", "type": "map[string]gs_meta.DBConfig", "sourceType": "*gs_meta.DB", "sourceLocation": "db.go:30"}
+		],
+		"properties": [
+			{"name": "db.*.hosts", "type": "[]string", "defaultValue": "", "sourceType": "gs_meta.DBConfig", "sourceLocation": "db.go:30"},
+			{"name": "db.*.url", "type": "string", "sourceType": "gs_meta.DBConfig", "sourceLocation": "db.go:30"},
+			{"name": "debug", "type": "bool", "defaultValue": "false", "sourceType": "*gs_meta.Server", "sourceLocation": "server.go:10"},
+			{"name": "http.addr", "type": "string", "defaultValue": ":8080", "validate": "hostport", "sourceType": "*gs_meta.Server", "sourceLocation": "server.go:10"},
+			{"name": "http.timeout", "type": "time.Duration", "defaultValue": "5s", "sourceType": "*gs_meta.Server", "sourceLocation": "server.go:10"},
+			{"name": "limit", "type": "int64", "defaultValue": "100", "expr": "$ > 0", "sourceType": "*gs_meta.Server", "sourceLocation": "server.go:10"},
+			{"name": "pool", "type": "int", "defaultValue": "4", "sourceType": "*gs_meta.DB", "sourceLocation": "db.go:30"},
+			{"name": "service.name", "type": "string", "sourceType": "*gs_meta.Service", "sourceLocation": "service.go:20"},
+			{"name": "service.ports", "type": "[]int", "defaultValue": "80,443", "sourceType": "*gs_meta.Service", "sourceLocation": "service.go:20"}
+		]
+	}`)
+
+	b, err = json.Marshal(m.Schema())
+	assert.That(t, err).Nil()
+	assert.String(t, string(b)).JSONEqual(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"db": {
+				"type": "object",
+				"additionalProperties": {
+					"type": "object",
+					"properties": {
+						"hosts": {"type": "array", "items": {"type": "string"}, "default": [], "x-go-type": "[]string", "x-source": "db.go:30"},
+						"url": {"type": "string", "x-go-type": "string", "x-source": "db.go:30"}
+					}
+				}
+			},
+			"debug": {"type": "boolean", "default": false, "x-go-type": "bool", "x-source": "server.go:10"},
+			"http": {
+				"type": "object",
+				"properties": {
+					"addr": {"type": "string", "default": ":8080", "x-go-type": "string", "x-source": "server.go:10", "x-validate": "hostport"},
+					"timeout": {"type": "string", "default": "5s", "x-go-type": "time.Duration", "x-source": "server.go:10"}
+				}
+			},
+			"limit": {"type": "integer", "default": 100, "x-go-type": "int64", "x-source": "server.go:10", "x-expr": "$ > 0"},
+			"pool": {"type": "integer", "default": 4, "x-go-type": "int", "x-source": "db.go:30"},
+			"service": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string", "x-go-type": "string", "x-source": "service.go:20"},
+					"ports": {"type": "array", "items": {"type": "integer"}, "default": [80, 443], "x-go-type": "[]int", "x-source": "service.go:20"}
+				}
+			}
+		}
+	}`)
+
+	dir := t.TempDir()
+	err = m.WriteFiles(dir)
+	assert.That(t, err).Nil()
+	for _, name := range []string{MetadataFile, SchemaFile} {
+		_, err = os.Stat(filepath.Join(dir, name))
+		assert.That(t, err).Nil()
+	}
+}